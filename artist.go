@@ -0,0 +1,167 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Alias is one of the names an artist is known by, and the script it is
+// written in.
+type Alias struct {
+	Name string
+	// Script is the name of the Unicode script most of the letters in
+	// Name belong to, e.g. "Latin" or "Katakana", or "" if there are no
+	// letters.
+	Script string
+}
+
+var closers = map[rune]rune{
+	'(': ')', '[': ']', '（': '）', '［': '］', '【': '】',
+}
+
+// ParseAliases splits an artist name such as "Sakanaction (サカナクション)"
+// or "Кедр ливанский [Kedr Livanskiy]" into its aliases. The name outside
+// the brackets is first, followed by each bracketed alternate. Brackets
+// that are tags rather than names, such as the country in "Varg (SE)" or
+// the number in "Varg (2)", are dropped.
+func ParseAliases(s string) []Alias {
+	var primary strings.Builder
+	var alternates []string
+	var alt strings.Builder
+	var closer rune
+	depth := 0
+	for _, r := range s {
+		switch {
+		case depth == 0 && closers[r] != 0:
+			closer = closers[r]
+			depth = 1
+			primary.WriteRune(' ')
+		case depth == 0:
+			primary.WriteRune(r)
+		case r == closer:
+			depth--
+			if depth == 0 {
+				alternates = append(alternates, alt.String())
+				alt.Reset()
+				continue
+			}
+			alt.WriteRune(r)
+		default:
+			if closers[r] == closer {
+				depth++
+			}
+			alt.WriteRune(r)
+		}
+	}
+	if depth > 0 {
+		alternates = append(alternates, alt.String())
+	}
+	var as []Alias
+	for i, n := range append([]string{primary.String()}, alternates...) {
+		n = CollapseSpace(StripZeroWidth(n))
+		if n == "" || i > 0 && isTag(n) {
+			continue
+		}
+		as = append(as, Alias{Name: n, Script: script(n)})
+	}
+	return as
+}
+
+// isTag returns whether s is a short all capital Latin tag, such as an ISO
+// country code, or a number, such as those used to tell apart artists with
+// the same name.
+func isTag(s string) bool {
+	digits := true
+	caps := len(s) >= 2 && len(s) <= 3
+	for _, r := range s {
+		digits = digits && r >= '0' && r <= '9'
+		caps = caps && r >= 'A' && r <= 'Z'
+	}
+	return digits || caps
+}
+
+// AliasNames returns the names of the aliases of s.
+func AliasNames(s string) []string {
+	as := ParseAliases(s)
+	ns := make([]string, len(as))
+	for i, a := range as {
+		ns[i] = a.Name
+	}
+	return ns
+}
+
+// WrapAliases takes a comparer and returns a comparer that compares
+// artist names by the maximum similarity of any pair of their aliases.
+func WrapAliases(f Comparer) Comparer {
+	return func(a, b string) float64 {
+		return ListSimilarity(AliasNames(a), AliasNames(b), f)
+	}
+}
+
+var scriptNames = func() []string {
+	ns := make([]string, 0, len(unicode.Scripts))
+	for n := range unicode.Scripts {
+		if n != "Common" && n != "Inherited" {
+			ns = append(ns, n)
+		}
+	}
+	sort.Strings(ns)
+	return ns
+}()
+
+// script returns the name of the script of most of the letters of s.
+// Letters are checked against Latin and then the script of the letter
+// before them, so that only the first letter of each run in another script
+// is checked against all of them.
+func script(s string) string {
+	counts := map[string]int{}
+	last := "Latin"
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		if unicode.Is(unicode.Latin, r) {
+			counts["Latin"]++
+			continue
+		}
+		if unicode.Is(unicode.Scripts[last], r) {
+			counts[last]++
+			continue
+		}
+		for _, n := range scriptNames {
+			if unicode.Is(unicode.Scripts[n], r) {
+				counts[n]++
+				last = n
+				break
+			}
+		}
+	}
+	max := ""
+	for _, n := range scriptNames {
+		if counts[n] > counts[max] {
+			max = n
+		}
+	}
+	return max
+}
@@ -0,0 +1,84 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charles-haynes/strsim"
+)
+
+func TestParseAliases(t *testing.T) {
+	for _, c := range []struct {
+		s string
+		e []strsim.Alias
+	}{
+		{"Sakanaction (サカナクション)", []strsim.Alias{
+			{"Sakanaction", "Latin"}, {"サカナクション", "Katakana"}}},
+		{"Roni Alter (רוני אלטר)", []strsim.Alias{
+			{"Roni Alter", "Latin"}, {"רוני אלטר", "Hebrew"}}},
+		{"Кедр ливанский [Kedr Livanskiy]", []strsim.Alias{
+			{"Кедр ливанский", "Cyrillic"}, {"Kedr Livanskiy", "Latin"}}},
+		{"Umm Kulthum (أم كلثوم\u200e)", []strsim.Alias{
+			{"Umm Kulthum", "Latin"}, {"أم كلثوم", "Arabic"}}},
+		{"Le Trio Joubran (الثلاثي جبران)", []strsim.Alias{
+			{"Le Trio Joubran", "Latin"}, {"الثلاثي جبران", "Arabic"}}},
+		{"Red Velvet (레드벨벳)", []strsim.Alias{
+			{"Red Velvet", "Latin"}, {"레드벨벳", "Hangul"}}},
+		{"Varg (SE)", []strsim.Alias{{"Varg", "Latin"}}},
+		{"Varg (2)", []strsim.Alias{{"Varg", "Latin"}}},
+		{"Mira (MIRA)", []strsim.Alias{{"Mira", "Latin"}, {"MIRA", "Latin"}}},
+		{"ILL (イル)", []strsim.Alias{{"ILL", "Latin"}, {"イル", "Katakana"}}},
+		{"A (B (C)) D", []strsim.Alias{{"A D", "Latin"}, {"B (C)", "Latin"}}},
+		{"070 Shake", []strsim.Alias{{"070 Shake", "Latin"}}},
+		{"2814 (", []strsim.Alias{{"2814", ""}}},
+	} {
+		if r := strsim.ParseAliases(c.s); !reflect.DeepEqual(r, c.e) {
+			t.Errorf("ParseAliases(%s) = %v, expected %v", c.s, r, c.e)
+		}
+	}
+}
+
+func TestWrapAliases(t *testing.T) {
+	f := strsim.WrapAliases(strsim.WrapNoCase(strsim.StringCompare))
+	for _, n := range [][]string{
+		{"Sakanaction (サカナクション)", "Sakanaction"},
+		{"Monari Wakita", "Monari Wakita (脇田もなり)"},
+		{"Kedr Livanskiy", "Кедр ливанский [Kedr Livanskiy]"},
+		{"Umm Kulthum (أم كلثوم\u200e)", "Umm Kulthum"},
+		{"Sana (さな)", "sana"},
+		{"biosphere (CA)", "Biosphere"},
+	} {
+		if r := f(n[0], n[1]); r != 1.0 {
+			t.Errorf("(%s,%s) = %5.3f, expected 1.0", n[0], n[1], r)
+		}
+	}
+	for _, n := range [][]string{
+		{"Varg (SE)", "Sweden"},
+		{"Varg (SE)", "SE"},
+		{"Varg (2)", "2"},
+	} {
+		if r := f(n[0], n[1]); r != 0.0 {
+			t.Errorf("(%s,%s) = %5.3f, expected 0.0", n[0], n[1], r)
+		}
+	}
+}
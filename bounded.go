@@ -0,0 +1,181 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim
+
+import (
+	"math"
+)
+
+// The bounded comparers return 0.0 for any pair of strings whose score
+// would be less than a minimum, and give up as soon as they can tell that
+// it will be, so that they are quicker when most pairs are dissimilar.
+
+// BoundedLevenshein returns a Levenshein comparer that returns 0.0 if the
+// score is less than bound. Strings whose lengths differ too much aren't
+// compared, and for long strings it only computes the band of the
+// distance matrix that a score of at least bound could pass through.
+func BoundedLevenshein(bound float64) Comparer {
+	return func(a, b string) float64 {
+		ra, rb := Runes(a, b)
+		n := len(ra) + len(rb)
+		// the most edits a score of at least bound allows, with slack
+		// for the rounding of 1 - bound
+		k := int(math.Floor((1-bound)*float64(n) + 1e-9))
+		if abs(len(ra)-len(rb)) > k {
+			return 0.0
+		}
+		var d int
+		if p, _ := shorter(ra, rb); len(p) > 64 && 8*(2*k+1) < len(p) {
+			var ok bool
+			if d, ok = bandedIndel(ra, rb, k); !ok {
+				return 0.0
+			}
+		} else {
+			// the bit-parallel algorithm is faster than a wide band
+			d = n - 2*bitLCS(ra, rb)
+		}
+		if s := 1.0 - float64(d)/float64(n); s >= bound {
+			return s
+		}
+		return 0.0
+	}
+}
+
+// bandedIndel returns the insertion and deletion distance between a and b
+// if it is at most k, using Ukkonen's banded dynamic programming.
+func bandedIndel(a, b []rune, k int) (int, bool) {
+	if abs(len(a)-len(b)) > k {
+		return 0, false
+	}
+	inf := len(a) + len(b) + 1
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+		if j > k {
+			prev[j] = inf
+		}
+	}
+	for i := 1; i <= len(a); i++ {
+		lo, hi := i-k, i+k
+		if lo < 1 {
+			lo = 1
+		}
+		if hi > len(b) {
+			hi = len(b)
+		}
+		if lo-1 >= 0 {
+			cur[lo-1] = inf
+		}
+		best := inf
+		if i <= k {
+			cur[0] = i
+			best = i + abs(len(a)-i-len(b))
+		}
+		for j := lo; j <= hi; j++ {
+			d := inf
+			if a[i-1] == b[j-1] {
+				d = prev[j-1]
+			}
+			d = min(d, prev[j]+1, cur[j-1]+1)
+			cur[j] = d
+			// the distance can't be less than d plus the difference in
+			// the lengths of what remains
+			if r := d + abs(len(a)-i-(len(b)-j)); r < best {
+				best = r
+			}
+		}
+		if hi+1 <= len(b) {
+			cur[hi+1] = inf
+		}
+		if best > k {
+			return 0, false
+		}
+		prev, cur = cur, prev
+	}
+	if prev[len(b)] > k {
+		return 0, false
+	}
+	return prev[len(b)], true
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// commonRunes returns the number of runes a and b have in common.
+func commonRunes(a, b []rune) int {
+	count := map[rune]int{}
+	for _, r := range a {
+		count[r]++
+	}
+	c := 0
+	for _, r := range b {
+		if count[r] > 0 {
+			count[r]--
+			c++
+		}
+	}
+	return c
+}
+
+// BoundedLCS returns an LCS comparer that returns 0.0 if the score is less
+// than bound. It skips finding the common substrings if there are too few
+// runes in common.
+func BoundedLCS(bound float64) Comparer {
+	return func(a, b string) float64 {
+		ra, rb := Runes(a, b)
+		c := commonRunes(ra, rb)
+		if float64(c)/float64(len(ra)+len(rb)-c) < bound {
+			return 0.0
+		}
+		if s := lcsRatio(ra, rb); s >= bound {
+			return s
+		}
+		return 0.0
+	}
+}
+
+// BoundedJaroWinkler returns a JaroWinkler comparer that returns 0.0 if the
+// score is less than bound. It stops matching runes once too few of the
+// remaining runes could match.
+func BoundedJaroWinkler(bound float64) Comparer {
+	return func(a, b string) float64 {
+		ra, rb := Runes(a, b)
+		return boundedJaroWinkler(ra, rb, bound)
+	}
+}
+
+// BoundedCommonTrigrams returns a CommonTrigrams comparer that returns 0.0
+// if the score is less than bound. It stops counting common trigrams once
+// too few of the remaining trigrams could be common.
+func BoundedCommonTrigrams(bound float64) Comparer {
+	return func(a, b string) float64 {
+		ra, rb := Runes(a, b)
+		if s := boundedTrigrams(ra, rb, bound); s >= bound {
+			return s
+		}
+		return 0.0
+	}
+}
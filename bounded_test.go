@@ -0,0 +1,94 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim_test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/charles-haynes/strsim"
+)
+
+func TestBounded(t *testing.T) {
+	for _, bound := range []float64{0.0, 0.3, 0.5, 0.7, 0.85, 0.9, 0.95,
+		0.99, 1.0, 1 - 2.0/598} {
+		for s, c := range map[string][]strsim.Comparer{
+			"levenshein": {strsim.Levenshein,
+				strsim.BoundedLevenshein(bound)},
+			"lcs": {strsim.LCS, strsim.BoundedLCS(bound)},
+			"jaro-winkler": {strsim.JaroWinkler,
+				strsim.BoundedJaroWinkler(bound)},
+			"common trigrams": {strsim.CommonTrigrams,
+				strsim.BoundedCommonTrigrams(bound)},
+		} {
+			check := func(a, b string) {
+				e := c[0](a, b)
+				if e < bound {
+					e = 0.0
+				}
+				if r := c[1](a, b); r != e {
+					t.Errorf("%s %4.2f(%s,%s) = %5.3f, expected %5.3f",
+						s, bound, a, b, r, e)
+				}
+			}
+			for _, n := range GroupsEqual {
+				check(n[0], n[1])
+			}
+			// pairs scoring exactly 0.9 and 1 - 2/598 by Levenshein
+			check("abcdefghijk", "abcdefghi")
+			long := strings.Repeat("abcdefghij", 30)
+			check(long, long[:298])
+			r := rand.New(rand.NewSource(1))
+			for i := 0; i < 100; i++ {
+				a := randomString(r, 1+r.Intn(20))
+				check(a, a[:len(a)/2]+randomString(r, r.Intn(5)))
+				if s == "levenshein" {
+					a = randomString(r, 1+r.Intn(1000))
+					check(a, a+randomString(r, r.Intn(20)))
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkBounded(b *testing.B) {
+	for s, f := range map[string]strsim.Comparer{
+		"levenshein":              strsim.Levenshein,
+		"bounded levenshein":      strsim.BoundedLevenshein(0.85),
+		"lcs":                     strsim.LCS,
+		"bounded lcs":             strsim.BoundedLCS(0.85),
+		"jaro-winkler":            strsim.JaroWinkler,
+		"bounded jaro-winkler":    strsim.BoundedJaroWinkler(0.85),
+		"common trigrams":         strsim.CommonTrigrams,
+		"bounded common trigrams": strsim.BoundedCommonTrigrams(0.85),
+	} {
+		b.Run(s, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, x := range GroupsEqual {
+					for _, y := range GroupsEqual {
+						_ = f(x[0], y[1])
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CreditPart is one of the artists in a joint credit, and the phrase that
+// joins it to the next artist in the credit.
+type CreditPart struct {
+	Name       string
+	JoinPhrase string
+}
+
+// DefaultJoinPhrases are the words and symbols that join the artists in a
+// joint credit. They leave out "+" and "x", which join the words of more
+// single acts' names, such as "Florence + the Machine", than of credits.
+var DefaultJoinPhrases = []string{"&", "and", "with", "vs", "vs.", "versus",
+	"feat", "feat.", "featuring", "ft", "ft."}
+
+// CreditParser splits joint credits into their artists.
+type CreditParser struct {
+	joinPhrase *regexp.Regexp
+}
+
+// DefaultCreditParser is the CreditParser for DefaultJoinPhrases, which
+// ParseCredit, CreditNames and NewCreditComparer use.
+var DefaultCreditParser = NewCreditParser(DefaultJoinPhrases...)
+
+// NewCreditParser returns a CreditParser that splits credits on the
+// phrases, ignoring case. Phrases that start with a letter must have space
+// on either side, others needn't.
+func NewCreditParser(phrases ...string) *CreditParser {
+	ps := make([]string, len(phrases))
+	for i, p := range phrases {
+		ps[i] = `\s*` + regexp.QuoteMeta(p) + `\s*`
+		if r, _ := utf8.DecodeRuneInString(p); unicode.IsLetter(r) {
+			ps[i] = `\s+` + regexp.QuoteMeta(p) + `\s+`
+		}
+	}
+	if len(ps) == 0 {
+		// match nothing
+		ps = []string{`[^\x00-\x{10FFFF}]`}
+	}
+	return &CreditParser{regexp.MustCompile(`(?i)` + strings.Join(ps, "|"))}
+}
+
+// ParseCredit splits a joint credit such as "Paul McCartney & Wings" or
+// "Stevie Ray Vaughan and Double Trouble" into its artists using
+// DefaultCreditParser.
+func ParseCredit(s string) []CreditPart {
+	return DefaultCreditParser.Parse(s)
+}
+
+// Parse splits a joint credit into its artists. The last artist has no
+// join phrase. An "&" or "and" between two single words, as in "Hall &
+// Oates", or after a list of words with commas, as in "Earth, Wind & Fire",
+// is taken as part of the name of a single act.
+func (p *CreditParser) Parse(s string) []CreditPart {
+	ms := p.joinPhrase.FindAllStringIndex(s, -1)
+	var cs []CreditPart
+	start := 0
+	for k, m := range ms {
+		if joinsName(s, ms, k) {
+			continue
+		}
+		if n := strings.TrimSpace(s[start:m[0]]); n != "" {
+			cs = append(cs, CreditPart{n, s[m[0]:m[1]]})
+		} else if len(cs) > 0 {
+			cs[len(cs)-1].JoinPhrase += s[m[0]:m[1]]
+		}
+		start = m[1]
+	}
+	if n := strings.TrimSpace(s[start:]); n != "" {
+		cs = append(cs, CreditPart{n, ""})
+	} else if len(cs) > 0 {
+		cs[len(cs)-1].JoinPhrase = ""
+	}
+	return cs
+}
+
+// joinsName returns whether the kth of the join phrases ms in s is part of
+// the name of a single act rather than a join between artists.
+func joinsName(s string, ms [][]int, k int) bool {
+	p := strings.ToLower(strings.TrimSpace(s[ms[k][0]:ms[k][1]]))
+	if p != "&" && p != "and" {
+		return false
+	}
+	before, after := s[:ms[k][0]], s[ms[k][1]:]
+	if k > 0 {
+		before = s[ms[k-1][1]:ms[k][0]]
+	}
+	if k+1 < len(ms) {
+		after = s[ms[k][1]:ms[k+1][0]]
+	}
+	before, after = strings.TrimSpace(before), strings.TrimSpace(after)
+	if before == "" || after == "" {
+		return false
+	}
+	return strings.Contains(before, ",") ||
+		len(strings.Fields(before)) == 1 && len(strings.Fields(after)) == 1
+}
+
+// CreditNames returns the distinct names of the artists in all the credits
+// in ss using DefaultCreditParser.
+func CreditNames(ss ...string) []string {
+	return DefaultCreditParser.Names(ss...)
+}
+
+// Names returns the distinct names of the artists in all the credits in
+// ss.
+func (p *CreditParser) Names(ss ...string) []string {
+	var ns []string
+	seen := map[string]bool{}
+	for _, s := range ss {
+		for _, c := range p.Parse(s) {
+			if !seen[c.Name] {
+				seen[c.Name] = true
+				ns = append(ns, c.Name)
+			}
+		}
+	}
+	return ns
+}
+
+// SetSimilarity pairs each of the as with one of the bs, best matches
+// first, and returns the total similarity of the pairs as a proportion of
+// the average number of as and bs.
+func SetSimilarity(as, bs []string, f Comparer) float64 {
+	if len(as) == 0 || len(bs) == 0 {
+		return 0.0
+	}
+	scores := make([][]float64, len(as))
+	for i, a := range as {
+		scores[i] = make([]float64, len(bs))
+		for j, b := range bs {
+			scores[i][j] = f(a, b)
+		}
+	}
+	usedA := make([]bool, len(as))
+	usedB := make([]bool, len(bs))
+	total := 0.0
+	for {
+		max, ai, bi := 0.0, -1, -1
+		for i := range as {
+			for j := range bs {
+				if !usedA[i] && !usedB[j] && scores[i][j] > max {
+					max, ai, bi = scores[i][j], i, j
+				}
+			}
+		}
+		if ai < 0 {
+			break
+		}
+		usedA[ai], usedB[bi] = true, true
+		total += max
+	}
+	return 2 * total / float64(len(as)+len(bs))
+}
+
+// CreditListSimilarity splits each of the as and bs into their artists and
+// compares the resulting sets using SetSimilarity, so that joint and
+// separate credits of the same artists match.
+func CreditListSimilarity(as, bs []string, f Comparer) float64 {
+	return SetSimilarity(CreditNames(as...), CreditNames(bs...), f)
+}
+
+// NewCreditComparer returns a comparer that compares two credits by the
+// SetSimilarity of their artists, using DefaultCreditParser.
+func NewCreditComparer(f Comparer) Comparer {
+	return DefaultCreditParser.Comparer(f)
+}
+
+// Comparer returns a comparer that compares two credits by the
+// SetSimilarity of their artists.
+func (p *CreditParser) Comparer(f Comparer) Comparer {
+	return func(a, b string) float64 {
+		return SetSimilarity(p.Names(a), p.Names(b), f)
+	}
+}
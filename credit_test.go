@@ -0,0 +1,108 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charles-haynes/strsim"
+)
+
+func TestParseCredit(t *testing.T) {
+	for _, c := range []struct {
+		s string
+		e []strsim.CreditPart
+	}{
+		{"Paul McCartney & Wings", []strsim.CreditPart{
+			{"Paul McCartney", " & "}, {"Wings", ""}}},
+		{"Stevie Ray Vaughan and Double Trouble", []strsim.CreditPart{
+			{"Stevie Ray Vaughan", " and "}, {"Double Trouble", ""}}},
+		{"Nick Cave & The Bad Seeds feat. Kylie Minogue", []strsim.CreditPart{
+			{"Nick Cave", " & "}, {"The Bad Seeds", " feat. "},
+			{"Kylie Minogue", ""}}},
+		{"Skepta x Chip vs. Wiley With Giggs", []strsim.CreditPart{
+			{"Skepta x Chip", " vs. "}, {"Wiley", " With "},
+			{"Giggs", ""}}},
+		{"Earth, Wind & Fire", []strsim.CreditPart{
+			{"Earth, Wind & Fire", ""}}},
+		{"Florence + the Machine", []strsim.CreditPart{
+			{"Florence + the Machine", ""}}},
+		{"Above & Beyond", []strsim.CreditPart{{"Above & Beyond", ""}}},
+		{"Hall & Oates", []strsim.CreditPart{{"Hall & Oates", ""}}},
+		{"Hall & Oates feat. Mick Jones", []strsim.CreditPart{
+			{"Hall & Oates", " feat. "}, {"Mick Jones", ""}}},
+		{"Bonzo Dog Doo/Dah Band", []strsim.CreditPart{
+			{"Bonzo Dog Doo/Dah Band", ""}}},
+		{"& Wings &", []strsim.CreditPart{{"Wings", ""}}},
+		{"", nil},
+	} {
+		if r := strsim.ParseCredit(c.s); !reflect.DeepEqual(r, c.e) {
+			t.Errorf("ParseCredit(%s) = %q, expected %q", c.s, r, c.e)
+		}
+	}
+	p := strsim.NewCreditParser("x", "+", "vs.")
+	for _, c := range []struct {
+		s string
+		e []strsim.CreditPart
+	}{
+		{"Skepta x Chip vs. Wiley", []strsim.CreditPart{
+			{"Skepta", " x "}, {"Chip", " vs. "}, {"Wiley", ""}}},
+		{"Dan+Dan", []strsim.CreditPart{{"Dan", "+"}, {"Dan", ""}}},
+		{"Maxx", []strsim.CreditPart{{"Maxx", ""}}},
+	} {
+		if r := p.Parse(c.s); !reflect.DeepEqual(r, c.e) {
+			t.Errorf("Parse(%s) = %q, expected %q", c.s, r, c.e)
+		}
+	}
+	p = strsim.NewCreditParser()
+	if r := p.Parse("Hall & Oates"); !reflect.DeepEqual(r,
+		[]strsim.CreditPart{{"Hall & Oates", ""}}) {
+		t.Errorf("Parse(Hall & Oates) = %q, expected it whole", r)
+	}
+}
+
+func TestCreditListSimilarity(t *testing.T) {
+	f := strsim.WrapNoCase(strsim.StringCompare)
+	for _, c := range []struct {
+		a, b []string
+		e    float64
+	}{
+		{[]string{"Paul McCartney & Wings"}, []string{"Paul McCartney", "Wings"}, 1.0},
+		{[]string{"Stevie Ray Vaughan and Double Trouble"}, []string{"Double Trouble", "Stevie Ray Vaughan", "Stevie Ray Vaughan & Double Trouble"}, 1.0},
+		{[]string{"Snowy White & The White Flames"}, []string{"Snowy White And The White Flames"}, 1.0},
+		{[]string{"Jim Peterik & World Stage"}, []string{"Jim Peterik"}, 2.0 / 3.0},
+		{[]string{"Pat Metheny"}, []string{"Leo Kottke"}, 0.0},
+		{[]string{"Earth, Wind & Fire"}, []string{"Earth, Wind & Fire"}, 1.0},
+		{[]string{"Earth, Wind & Fire"}, []string{"Fire"}, 0.0},
+		{[]string{"Above & Beyond"}, []string{"Beyond"}, 0.0},
+	} {
+		if r := strsim.CreditListSimilarity(c.a, c.b, f); r != c.e {
+			t.Errorf("CreditListSimilarity(%v,%v) = %5.3f, expected %5.3f",
+				c.a, c.b, r, c.e)
+		}
+	}
+	cf := strsim.NewCreditComparer(f)
+	if r := cf("Nick Cave & The Bad Seeds", "Nick Cave and The Bad Seeds"); r != 1.0 {
+		t.Errorf("(Nick Cave & The Bad Seeds,Nick Cave and The Bad Seeds) = "+
+			"%5.3f, expected 1.0", r)
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Date is a date in a title. Month and Day are 0 when the title only gives
+// the year, or the year and month.
+type Date struct {
+	Year, Month, Day int
+}
+
+// DateRange is a date or range of dates in a title. For a single date From
+// and To are the same.
+type DateRange struct {
+	From, To Date
+}
+
+const year = `(?:1[0-9]{3}|20[0-9]{2})`
+
+var (
+	date = `(?:` + year + `-[0-9]{2}-[0-9]{2}` +
+		`|` + year + `\s*[-–]\s*` + year +
+		`|` + year + `-(?:0[1-9]|1[0-2])` +
+		`|` + year + `\s*[-–]\s*[0-9]{2}` +
+		`|` + year + `)\b`
+	leadingDate  = regexp.MustCompile(`^\s*(` + date + `)(?:\s*[-–:,]\s*|\s+|$)`)
+	trailingDate = regexp.MustCompile(`(^|\s*[-–:,(]\s*|\s+)(` + date + `)\s*([)\]]?)\s*$`)
+	dateFields   = regexp.MustCompile(`[0-9]+|[-–]`)
+)
+
+// parseDate parses text matched by the date regexp.
+func parseDate(s string) DateRange {
+	var d DateRange
+	fs := dateFields.FindAllString(s, -1)
+	d.From.Year, _ = strconv.Atoi(fs[0])
+	switch {
+	case len(fs) == 5:
+		d.From.Month, _ = strconv.Atoi(fs[2])
+		d.From.Day, _ = strconv.Atoi(fs[4])
+	case len(fs) == 3 && len(fs[2]) == 4:
+		d.To.Year, _ = strconv.Atoi(fs[2])
+		return d
+	case len(fs) == 3 && strings.Contains(s, "-") &&
+		!strings.ContainsAny(s, " –") && fs[2] <= "12":
+		d.From.Month, _ = strconv.Atoi(fs[2])
+	case len(fs) == 3:
+		y, _ := strconv.Atoi(fs[2])
+		d.To.Year = d.From.Year/100*100 + y
+		if d.To.Year < d.From.Year {
+			d.To.Year += 100
+		}
+		return d
+	}
+	d.To = d.From
+	return d
+}
+
+// ParseDates removes a leading and a trailing date or range of dates from
+// a title such as "2019-02-23 - Barceló Maya Beach" or "Nigeria 70: Juju
+// 1973-1987" and returns what is left of the title and the dates.
+func ParseDates(s string) (string, []DateRange) {
+	var ds []DateRange
+	if m := leadingDate.FindStringSubmatchIndex(s); m != nil {
+		ds = append(ds, parseDate(s[m[2]:m[3]]))
+		s = s[m[1]:]
+	}
+	if m := trailingDate.FindStringSubmatchIndex(s); m != nil {
+		ds = append(ds, parseDate(s[m[4]:m[5]]))
+		end := s[m[6]:m[7]]
+		if strings.Contains(s[m[2]:m[3]], "(") {
+			end = ""
+		}
+		s = s[:m[0]] + end
+	}
+	return strings.TrimSpace(s), ds
+}
+
+// precise returns whether d gives more than the year.
+func (d Date) precise() bool {
+	return d.Month != 0
+}
+
+// DateSimilarity compares two dates or ranges of dates. Dates which are
+// the same, or where one only gives the year and the other is in that
+// year, are 1.0. Different dates given to the month or day are 0.0.
+// Otherwise it is the proportion of years the ranges share.
+func DateSimilarity(a, b DateRange) float64 {
+	if a == b {
+		return 1.0
+	}
+	if a.From == a.To && b.From == b.To && a.From.precise() &&
+		b.From.precise() {
+		if a.From.Year == b.From.Year && (a.From.Day == 0 ||
+			b.From.Day == 0) && a.From.Month == b.From.Month {
+			return 1.0
+		}
+		return 0.0
+	}
+	lo, hi := a.From.Year, a.To.Year
+	if b.From.Year > lo {
+		lo = b.From.Year
+	}
+	if b.To.Year < hi {
+		hi = b.To.Year
+	}
+	if hi < lo {
+		return 0.0
+	}
+	span := a.To.Year - a.From.Year + b.To.Year - b.From.Year + 2
+	return float64(hi-lo+1) / float64(span-(hi-lo+1))
+}
+
+// DefaultDateWeight is the weight of the dates in WrapDates.
+const DefaultDateWeight = 0.25
+
+// NewDatedComparer returns a comparer that compares titles without their
+// leading and trailing dates using f, and their dates using
+// DateSimilarity, weighting the dates by weight. If either title has no
+// dates only the rest of the title is compared.
+func NewDatedComparer(f Comparer, weight float64) Comparer {
+	return func(a, b string) float64 {
+		ra, da := ParseDates(a)
+		rb, db := ParseDates(b)
+		s := compareParts(f, ra, rb)
+		if len(da) == 0 || len(db) == 0 {
+			return s
+		}
+		d := 0.0
+		for _, x := range da {
+			for _, y := range db {
+				if m := DateSimilarity(x, y); m > d {
+					d = m
+				}
+			}
+		}
+		return (1-weight)*s + weight*d
+	}
+}
+
+// WrapDates takes a comparer and returns a NewDatedComparer using
+// DefaultDateWeight.
+func WrapDates(f Comparer) Comparer {
+	return NewDatedComparer(f, DefaultDateWeight)
+}
@@ -0,0 +1,110 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/charles-haynes/strsim"
+)
+
+func TestParseDates(t *testing.T) {
+	year := func(f, t int) strsim.DateRange {
+		return strsim.DateRange{strsim.Date{Year: f}, strsim.Date{Year: t}}
+	}
+	for _, c := range []struct {
+		s, r string
+		d    []strsim.DateRange
+	}{
+		{"2019-02-23 Barceló Maya Beach, Riviera Maya", "Barceló Maya Beach, Riviera Maya",
+			[]strsim.DateRange{{strsim.Date{2019, 2, 23}, strsim.Date{2019, 2, 23}}}},
+		{"2019-02-23 - Barceló Maya Beach Resort", "Barceló Maya Beach Resort",
+			[]strsim.DateRange{{strsim.Date{2019, 2, 23}, strsim.Date{2019, 2, 23}}}},
+		{"2019-02: February", "February",
+			[]strsim.DateRange{{strsim.Date{2019, 2, 0}, strsim.Date{2019, 2, 0}}}},
+		{"Three Day Week (When The Lights Went Out 1972 - 1975)", "Three Day Week (When The Lights Went Out)",
+			[]strsim.DateRange{year(1972, 1975)}},
+		{"Three Day Week: When The Lights Went Out 1972–1975", "Three Day Week: When The Lights Went Out",
+			[]strsim.DateRange{year(1972, 1975)}},
+		{"Remind Me: The Classic Elektra Recordings 1978-1984", "Remind Me: The Classic Elektra Recordings",
+			[]strsim.DateRange{year(1978, 1984)}},
+		{"Sounds from the Two Congos (1955-62)", "Sounds from the Two Congos",
+			[]strsim.DateRange{year(1955, 1962)}},
+		{"Live At Carnegie Hall 1977", "Live At Carnegie Hall", []strsim.DateRange{year(1977, 1977)}},
+		{"1999", "", []strsim.DateRange{year(1999, 1999)}},
+		{"Anjunadeep 10", "Anjunadeep 10", nil},
+		{"Warehouse 2000X", "Warehouse 2000X", nil},
+	} {
+		r, d := strsim.ParseDates(c.s)
+		if r != c.r || !reflect.DeepEqual(d, c.d) {
+			t.Errorf("ParseDates(%s) = %q, %v, expected %q, %v",
+				c.s, r, d, c.r, c.d)
+		}
+	}
+}
+
+func TestDateSimilarity(t *testing.T) {
+	d := func(y, m, dd int) strsim.DateRange {
+		return strsim.DateRange{strsim.Date{y, m, dd}, strsim.Date{y, m, dd}}
+	}
+	year := func(f, t int) strsim.DateRange {
+		return strsim.DateRange{strsim.Date{Year: f}, strsim.Date{Year: t}}
+	}
+	for _, c := range []struct {
+		a, b strsim.DateRange
+		e    float64
+	}{
+		{d(2019, 2, 23), d(2019, 2, 23), 1.0},
+		{d(2019, 2, 23), d(2019, 2, 24), 0.0},
+		{d(2019, 2, 23), d(2019, 2, 0), 1.0},
+		{d(2019, 2, 23), year(2019, 2019), 1.0},
+		{year(2001, 2006), year(2001, 2006), 1.0},
+		{year(1972, 1975), year(1974, 1977), 2.0 / 6.0},
+		{year(1977, 1977), year(1978, 1980), 0.0},
+	} {
+		if r := strsim.DateSimilarity(c.a, c.b); math.Abs(r-c.e) > 1e-9 {
+			t.Errorf("DateSimilarity(%v,%v) = %5.3f, expected %5.3f",
+				c.a, c.b, r, c.e)
+		}
+	}
+}
+
+func TestDatedComparer(t *testing.T) {
+	for s, f := range Sims {
+		df := strsim.WrapDates(f)
+		for _, n := range [][]string{
+			{"My Laptops 2001 - 2006", "My Laptops 2001-2006"},
+			{"2019-02-23 Barceló", "2019-02-23 - Barceló"},
+			{"1999", "1999"},
+		} {
+			if r := df(n[0], n[1]); r != 1.0 {
+				t.Errorf("%s(%s,%s) = %5.3f, expected 1.0",
+					s, n[0], n[1], r)
+			}
+		}
+		if r := df("2019-02-23 Barceló", "2019-02-24 Barceló"); r != 0.75 {
+			t.Errorf("%s(2019-02-23 Barceló,2019-02-24 Barceló) = %5.3f, "+
+				"expected 0.75", s, r)
+		}
+	}
+}
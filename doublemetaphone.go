@@ -0,0 +1,570 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim
+
+import (
+	"strings"
+)
+
+// doubleMetaphoneLen is the length Double Metaphone codes are cut to.
+const doubleMetaphoneLen = 4
+
+// DoubleMetaphone returns the Double Metaphone code of word, followed by
+// its alternate code if it has a different one, as Lawrence Philips
+// describes it and Apache Commons Codec implements it.
+func DoubleMetaphone(word string) []string {
+	d := doubleMetaphone{
+		w: phoneticLetters(strings.NewReplacer("ç", "s", "Ç", "S").
+			Replace(word), true),
+	}
+	d.encode()
+	p, a := string(d.primary), string(d.alternate)
+	switch {
+	case p == "" && a == "":
+		return nil
+	case p == a:
+		return []string{p}
+	}
+	return []string{p, a}
+}
+
+type doubleMetaphone struct {
+	w                  []byte
+	slavoGermanic      bool
+	primary, alternate []byte
+}
+
+// add appends p to the primary code and a to the alternate code, cut to
+// doubleMetaphoneLen.
+func (d *doubleMetaphone) add(p, a string) {
+	d.primary = appendCut(d.primary, p)
+	d.alternate = appendCut(d.alternate, a)
+}
+
+// add1 appends s to both codes.
+func (d *doubleMetaphone) add1(s string) {
+	d.add(s, s)
+}
+
+func appendCut(b []byte, s string) []byte {
+	if n := doubleMetaphoneLen - len(b); len(s) > n {
+		s = s[:n]
+	}
+	return append(b, s...)
+}
+
+func (d *doubleMetaphone) complete() bool {
+	return len(d.primary) >= doubleMetaphoneLen &&
+		len(d.alternate) >= doubleMetaphoneLen
+}
+
+// at returns the letter at i, or 0 if i is outside the word.
+func (d *doubleMetaphone) at(i int) byte {
+	return at(d.w, i)
+}
+
+// is reports whether the n letters from start are one of ss.
+func (d *doubleMetaphone) is(start, n int, ss ...string) bool {
+	if start < 0 || start+n > len(d.w) {
+		return false
+	}
+	t := string(d.w[start : start+n])
+	for _, s := range ss {
+		if t == s {
+			return true
+		}
+	}
+	return false
+}
+
+// vowel reports whether the letter at i is a vowel, including Y.
+func (d *doubleMetaphone) vowel(i int) bool {
+	c := d.at(i)
+	return c != 0 && strings.IndexByte("AEIOUY", c) >= 0
+}
+
+// germanic reports whether the word starts like a Germanic name.
+func (d *doubleMetaphone) germanic() bool {
+	return d.is(0, 4, "VAN ", "VON ") || d.is(0, 3, "SCH")
+}
+
+// skip returns the index after i, or after the letter following i if it
+// is one of cs.
+func (d *doubleMetaphone) skip(i int, cs string) int {
+	if c := d.at(i + 1); c != 0 && strings.IndexByte(cs, c) >= 0 {
+		return i + 2
+	}
+	return i + 1
+}
+
+func (d *doubleMetaphone) encode() {
+	w := string(d.w)
+	d.slavoGermanic = strings.Contains(w, "W") ||
+		strings.Contains(w, "K") || strings.Contains(w, "CZ") ||
+		strings.Contains(w, "WITZ")
+	i := 0
+	if d.is(0, 2, "GN", "KN", "PN", "WR", "PS") {
+		i = 1
+	}
+	for !d.complete() && i < len(d.w) {
+		switch d.w[i] {
+		case 'A', 'E', 'I', 'O', 'U', 'Y':
+			if i == 0 {
+				d.add1("A")
+			}
+			i++
+		case 'B':
+			d.add1("P")
+			i = d.skip(i, "B")
+		case 'C':
+			i = d.c(i)
+		case 'D':
+			i = d.d(i)
+		case 'F':
+			d.add1("F")
+			i = d.skip(i, "F")
+		case 'G':
+			i = d.g(i)
+		case 'H':
+			i = d.h(i)
+		case 'J':
+			i = d.j(i)
+		case 'K':
+			d.add1("K")
+			i = d.skip(i, "K")
+		case 'L':
+			i = d.l(i)
+		case 'M':
+			d.add1("M")
+			if d.at(i+1) == 'M' || d.is(i-1, 3, "UMB") &&
+				(i+1 == len(d.w)-1 || d.is(i+2, 2, "ER")) {
+				i += 2
+			} else {
+				i++
+			}
+		case 'N':
+			d.add1("N")
+			i = d.skip(i, "N")
+		case 'P':
+			if d.at(i+1) == 'H' {
+				d.add1("F")
+				i += 2
+			} else {
+				d.add1("P")
+				i = d.skip(i, "PB")
+			}
+		case 'Q':
+			d.add1("K")
+			i = d.skip(i, "Q")
+		case 'R':
+			if i == len(d.w)-1 && !d.slavoGermanic &&
+				d.is(i-2, 2, "IE") && !d.is(i-4, 2, "ME", "MA") {
+				d.add("", "R")
+			} else {
+				d.add1("R")
+			}
+			i = d.skip(i, "R")
+		case 'S':
+			i = d.s(i)
+		case 'T':
+			i = d.t(i)
+		case 'V':
+			d.add1("F")
+			i = d.skip(i, "V")
+		case 'W':
+			i = d.wh(i)
+		case 'X':
+			i = d.x(i)
+		case 'Z':
+			i = d.z(i)
+		default:
+			i++
+		}
+	}
+}
+
+func (d *doubleMetaphone) c(i int) int {
+	switch {
+	case d.c0(i):
+		d.add1("K")
+		return i + 2
+	case i == 0 && d.is(i, 6, "CAESAR"):
+		d.add1("S")
+		return i + 2
+	case d.is(i, 2, "CH"):
+		return d.ch(i)
+	case d.is(i, 2, "CZ") && !d.is(i-2, 4, "WICZ"):
+		// "Czerny"
+		d.add("S", "X")
+		return i + 2
+	case d.is(i+1, 3, "CIA"):
+		// "focaccia"
+		d.add1("X")
+		return i + 3
+	case d.is(i, 2, "CC") && !(i == 1 && d.at(0) == 'M'):
+		// double "cc" but not "McClelland"
+		if d.is(i+2, 1, "I", "E", "H") && !d.is(i+2, 2, "HU") {
+			// "bellocchio" but not "bacchus"
+			if i == 1 && d.at(i-1) == 'A' || d.is(i-1, 5, "UCCEE", "UCCES") {
+				// "accident", "accede", "succeed"
+				d.add1("KS")
+			} else {
+				// "bacci", "bertucci", other Italian
+				d.add1("X")
+			}
+			return i + 3
+		}
+		// Pierce's rule
+		d.add1("K")
+		return i + 2
+	case d.is(i, 2, "CK", "CG", "CQ"):
+		d.add1("K")
+		return i + 2
+	case d.is(i, 2, "CI", "CE", "CY"):
+		// Italian vs. English
+		if d.is(i, 3, "CIO", "CIE", "CIA") {
+			d.add("S", "X")
+		} else {
+			d.add1("S")
+		}
+		return i + 2
+	}
+	d.add1("K")
+	switch {
+	case d.is(i+1, 2, " C", " Q", " G"):
+		// "Mac Caffrey", "Mac Gregor"
+		return i + 3
+	case d.is(i+1, 1, "C", "K", "Q") && !d.is(i+1, 2, "CE", "CI"):
+		return i + 2
+	}
+	return i + 1
+}
+
+func (d *doubleMetaphone) c0(i int) bool {
+	switch {
+	case d.is(i, 4, "CHIA"):
+		return true
+	case i <= 1, d.vowel(i - 2), !d.is(i-1, 3, "ACH"):
+		return false
+	}
+	c := d.at(i + 2)
+	return c != 'I' && c != 'E' || d.is(i-2, 6, "BACHER", "MACHER")
+}
+
+func (d *doubleMetaphone) ch(i int) int {
+	switch {
+	case i > 0 && d.is(i, 4, "CHAE"):
+		// "Michael"
+		d.add("K", "X")
+	case i == 0 && (d.is(i+1, 5, "HARAC", "HARIS") ||
+		d.is(i+1, 3, "HOR", "HYM", "HIA", "HEM")) && !d.is(0, 5, "CHORE"):
+		// Greek roots, "chemistry", "chorus"
+		d.add1("K")
+	case d.germanic() ||
+		d.is(i-2, 6, "ORCHES", "ARCHIT", "ORCHID") ||
+		d.is(i+2, 1, "T", "S") ||
+		(d.is(i-1, 1, "A", "O", "U", "E") || i == 0) &&
+			(d.is(i+2, 1, "L", "R", "N", "M", "B", "H", "F", "V", "W", " ") ||
+				i+1 == len(d.w)-1):
+		// Germanic, Greek, or otherwise "ch" for "kh"
+		d.add1("K")
+	case i == 0:
+		d.add1("X")
+	case d.is(0, 2, "MC"):
+		d.add1("K")
+	default:
+		d.add("X", "K")
+	}
+	return i + 2
+}
+
+func (d *doubleMetaphone) d(i int) int {
+	switch {
+	case d.is(i, 2, "DG"):
+		if d.is(i+2, 1, "I", "E", "Y") {
+			// "edge"
+			d.add1("J")
+			return i + 3
+		}
+		// "Edgar"
+		d.add1("TK")
+		return i + 2
+	case d.is(i, 2, "DT", "DD"):
+		d.add1("T")
+		return i + 2
+	}
+	d.add1("T")
+	return i + 1
+}
+
+func (d *doubleMetaphone) g(i int) int {
+	switch {
+	case d.at(i+1) == 'H':
+		return d.gh(i)
+	case d.at(i+1) == 'N':
+		switch {
+		case i == 1 && d.vowel(0) && !d.slavoGermanic:
+			d.add("KN", "N")
+		case !d.is(i+2, 2, "EY") && d.at(i+1) != 'Y' && !d.slavoGermanic:
+			d.add("N", "KN")
+		default:
+			d.add1("KN")
+		}
+		return i + 2
+	case d.is(i+1, 2, "LI") && !d.slavoGermanic:
+		d.add("KL", "L")
+		return i + 2
+	case i == 0 && (d.at(i+1) == 'Y' || d.is(i+1, 2, "ES", "EP", "EB", "EL",
+		"EY", "IB", "IL", "IN", "IE", "EI", "ER")):
+		// -ges-, -gep-, -gel-, -gie- at the beginning
+		d.add("K", "J")
+		return i + 2
+	case (d.is(i+1, 2, "ER") || d.at(i+1) == 'Y') &&
+		!d.is(0, 6, "DANGER", "RANGER", "MANGER") &&
+		!d.is(i-1, 1, "E", "I") && !d.is(i-1, 3, "RGY", "OGY"):
+		// -ger-, -gy-
+		d.add("K", "J")
+		return i + 2
+	case d.is(i+1, 1, "E", "I", "Y") || d.is(i-1, 4, "AGGI", "OGGI"):
+		// Italian "biaggi"
+		switch {
+		case d.germanic() || d.is(i+1, 2, "ET"):
+			d.add1("K")
+		case d.is(i+1, 3, "IER"):
+			d.add1("J")
+		default:
+			d.add("J", "K")
+		}
+		return i + 2
+	}
+	d.add1("K")
+	return d.skip(i, "G")
+}
+
+func (d *doubleMetaphone) gh(i int) int {
+	switch {
+	case i > 0 && !d.vowel(i-1):
+		d.add1("K")
+	case i == 0:
+		if d.at(i+2) == 'I' {
+			d.add1("J")
+		} else {
+			d.add1("K")
+		}
+	case i > 1 && d.is(i-2, 1, "B", "H", "D") ||
+		i > 2 && d.is(i-3, 1, "B", "H", "D") ||
+		i > 3 && d.is(i-4, 1, "B", "H"):
+		// Parker's rule, "hugh"
+	case i > 2 && d.at(i-1) == 'U' && d.is(i-3, 1, "C", "G", "L", "R", "T"):
+		// "laugh", "McLaughlin", "cough", "gough", "rough", "tough"
+		d.add1("F")
+	case d.at(i-1) != 'I':
+		d.add1("K")
+	}
+	return i + 2
+}
+
+func (d *doubleMetaphone) h(i int) int {
+	// only kept if first or between vowels
+	if (i == 0 || d.vowel(i-1)) && d.vowel(i+1) {
+		d.add1("H")
+		return i + 2
+	}
+	return i + 1
+}
+
+func (d *doubleMetaphone) j(i int) int {
+	if d.is(i, 4, "JOSE") || d.is(0, 4, "SAN ") {
+		// Spanish, "Jose", "San Jacinto"
+		if i == 0 && d.at(i+4) == ' ' || len(d.w) == 4 ||
+			d.is(0, 4, "SAN ") {
+			d.add1("H")
+		} else {
+			d.add("J", "H")
+		}
+		return i + 1
+	}
+	switch {
+	case i == 0:
+		d.add("J", "A")
+	case d.vowel(i-1) && !d.slavoGermanic &&
+		(d.at(i+1) == 'A' || d.at(i+1) == 'O'):
+		d.add("J", "H")
+	case i == len(d.w)-1:
+		d.add("J", "")
+	case !d.is(i+1, 1, "L", "T", "K", "S", "N", "M", "B", "Z") &&
+		!d.is(i-1, 1, "S", "K", "L"):
+		d.add1("J")
+	}
+	return d.skip(i, "J")
+}
+
+func (d *doubleMetaphone) l(i int) int {
+	if d.at(i+1) != 'L' {
+		d.add1("L")
+		return i + 1
+	}
+	n := len(d.w)
+	if i == n-3 && d.is(i-1, 4, "ILLO", "ILLA", "ALLE") ||
+		(d.is(n-2, 2, "AS", "OS") || d.is(n-1, 1, "A", "O")) &&
+			d.is(i-1, 4, "ALLE") {
+		// Spanish, "cabrillo", "gallegos"
+		d.add("L", "")
+	} else {
+		d.add1("L")
+	}
+	return i + 2
+}
+
+func (d *doubleMetaphone) s(i int) int {
+	switch {
+	case d.is(i-1, 3, "ISL", "YSL"):
+		// "island", "isle", "carlisle", "carlysle"
+		return i + 1
+	case i == 0 && d.is(i, 5, "SUGAR"):
+		d.add("X", "S")
+		return i + 1
+	case d.is(i, 2, "SH"):
+		if d.is(i+1, 4, "HEIM", "HOEK", "HOLM", "HOLZ") {
+			// Germanic
+			d.add1("S")
+		} else {
+			d.add1("X")
+		}
+		return i + 2
+	case d.is(i, 3, "SIO", "SIA") || d.is(i, 4, "SIAN"):
+		// Italian and Armenian
+		if d.slavoGermanic {
+			d.add1("S")
+		} else {
+			d.add("S", "X")
+		}
+		return i + 3
+	case i == 0 && d.is(i+1, 1, "M", "N", "L", "W") || d.is(i+1, 1, "Z"):
+		// German and anglicisations, "smith" matches "schmidt", and
+		// Slavic -sz-
+		d.add("S", "X")
+		return d.skip(i, "Z")
+	case d.is(i, 2, "SC"):
+		return d.sc(i)
+	}
+	if i == len(d.w)-1 && d.is(i-2, 2, "AI", "OI") {
+		// French, "resnais", "artois"
+		d.add("", "S")
+	} else {
+		d.add1("S")
+	}
+	return d.skip(i, "SZ")
+}
+
+func (d *doubleMetaphone) sc(i int) int {
+	switch {
+	case d.at(i+2) == 'H':
+		// Schlesinger's rule
+		switch {
+		case d.is(i+3, 2, "ER", "EN"):
+			// "schermerhorn", "schenker"
+			d.add("X", "SK")
+		case d.is(i+3, 2, "OO", "UY", "ED", "EM"):
+			// Dutch, "school", "schooner"
+			d.add1("SK")
+		case i == 0 && !d.vowel(3) && d.at(3) != 'W':
+			d.add("X", "S")
+		default:
+			d.add1("X")
+		}
+	case d.is(i+2, 1, "I", "E", "Y"):
+		d.add1("S")
+	default:
+		d.add1("SK")
+	}
+	return i + 3
+}
+
+func (d *doubleMetaphone) t(i int) int {
+	switch {
+	case d.is(i, 4, "TION"), d.is(i, 3, "TIA", "TCH"):
+		d.add1("X")
+		return i + 3
+	case d.is(i, 2, "TH") || d.is(i, 3, "TTH"):
+		if d.is(i+2, 2, "OM", "AM") || d.germanic() {
+			// "thomas", "thames"
+			d.add1("T")
+		} else {
+			d.add("0", "T")
+		}
+		return i + 2
+	}
+	d.add1("T")
+	return d.skip(i, "TD")
+}
+
+func (d *doubleMetaphone) wh(i int) int {
+	switch {
+	case d.is(i, 2, "WR"):
+		d.add1("R")
+		return i + 2
+	case i == 0 && d.vowel(i+1):
+		// "Wasserman" matches "Vasserman"
+		d.add("A", "F")
+	case i == 0 && d.is(i, 2, "WH"):
+		// "Uomo" matches "Womo"
+		d.add1("A")
+	case i == len(d.w)-1 && d.vowel(i-1) ||
+		d.is(i-1, 5, "EWSKI", "EWSKY", "OWSKI", "OWSKY") ||
+		d.is(0, 3, "SCH"):
+		// "Arnow" matches "Arnoff"
+		d.add("", "F")
+	case d.is(i, 4, "WICZ", "WITZ"):
+		// Polish, "filipowicz"
+		d.add("TS", "FX")
+		return i + 4
+	}
+	return i + 1
+}
+
+func (d *doubleMetaphone) x(i int) int {
+	if i == 0 {
+		d.add1("S")
+		return i + 1
+	}
+	if !(i == len(d.w)-1 &&
+		(d.is(i-3, 3, "IAU", "EAU") || d.is(i-2, 2, "AU", "OU"))) {
+		// not French, "breaux"
+		d.add1("KS")
+	}
+	return d.skip(i, "CX")
+}
+
+func (d *doubleMetaphone) z(i int) int {
+	if d.at(i+1) == 'H' {
+		// Chinese pinyin, "zhao"
+		d.add1("J")
+		return i + 2
+	}
+	if d.is(i+1, 2, "ZO", "ZI", "ZA") ||
+		d.slavoGermanic && i > 0 && d.at(i-1) != 'T' {
+		d.add("S", "TS")
+	} else {
+		d.add1("S")
+	}
+	return d.skip(i, "Z")
+}
@@ -0,0 +1,150 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim
+
+// EditCosts are the costs of each kind of edit for the edit distance
+// comparers.
+type EditCosts struct {
+	Insert, Delete, Substitute, Transpose int
+}
+
+// DefaultEditCosts count a substitution as a deletion and an insertion, as
+// Levenshein does, and a transposition of adjacent runes as a single edit.
+var DefaultEditCosts = EditCosts{Insert: 1, Delete: 1, Substitute: 2,
+	Transpose: 1}
+
+// normalize returns the similarity of a and b given the distance d
+// between them with costs c, normalized by the cost of deleting all of a
+// and inserting all of b.
+func (c EditCosts) normalize(d int, a, b []rune) float64 {
+	max := len(a)*c.Delete + len(b)*c.Insert
+	if max == 0 {
+		return 1.0
+	}
+	return 1.0 - float64(d)/float64(max)
+}
+
+func min(a int, bs ...int) int {
+	for _, b := range bs {
+		if b < a {
+			a = b
+		}
+	}
+	return a
+}
+
+// osa returns the optimal string alignment distance between a and b, the
+// edit distance where transposed runes may not be edited further.
+func osa(a, b []rune, c EditCosts) int {
+	// row i of the distance matrix is rows[i%3]
+	var rows [3][]int
+	for i := range rows {
+		rows[i] = make([]int, len(b)+1)
+	}
+	for j := range rows[0] {
+		rows[0][j] = j * c.Insert
+	}
+	for i := 1; i <= len(a); i++ {
+		cur, prev, prev2 := rows[i%3], rows[(i+2)%3], rows[(i+1)%3]
+		cur[0] = i * c.Delete
+		for j := 1; j <= len(b); j++ {
+			sub := c.Substitute
+			if a[i-1] == b[j-1] {
+				sub = 0
+			}
+			cur[j] = min(prev[j]+c.Delete, cur[j-1]+c.Insert,
+				prev[j-1]+sub)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+c.Transpose)
+			}
+		}
+	}
+	return rows[len(a)%3][len(b)]
+}
+
+// damerauLevenshtein returns the edit distance between a and b allowing
+// transpositions of adjacent runes, even if they are edited further. It
+// requires 2*c.Transpose >= c.Insert+c.Delete.
+func damerauLevenshtein(a, b []rune, c EditCosts) int {
+	inf := len(a)*c.Delete + len(b)*c.Insert + 1
+	d := make([][]int, len(a)+2)
+	for i := range d {
+		d[i] = make([]int, len(b)+2)
+		d[i][0] = inf
+		if i > 0 {
+			d[i][1] = (i - 1) * c.Delete
+		}
+	}
+	for j := 1; j <= len(b)+1; j++ {
+		d[0][j] = inf
+		d[1][j] = (j - 1) * c.Insert
+	}
+	// last is the last row of a each rune was seen in
+	last := map[rune]int{}
+	for i := 1; i <= len(a); i++ {
+		lastCol := 0
+		for j := 1; j <= len(b); j++ {
+			i1, j1 := last[b[j-1]], lastCol
+			sub := c.Substitute
+			if a[i-1] == b[j-1] {
+				sub = 0
+				lastCol = j
+			}
+			d[i+1][j+1] = min(d[i][j]+sub, d[i+1][j]+c.Insert,
+				d[i][j+1]+c.Delete,
+				d[i1][j1]+(i-i1-1)*c.Delete+c.Transpose+(j-j1-1)*c.Insert)
+		}
+		last[a[i-1]] = i
+	}
+	return d[len(a)+1][len(b)+1]
+}
+
+// DamerauLevenshtein returns the edit distance between the runes of a and
+// b, allowing adjacent runes to be transposed, using DefaultEditCosts and
+// normalized to [0.0..1.0] as Levenshein is.
+func DamerauLevenshtein(a, b string) float64 {
+	return NewDamerauLevenshtein(DefaultEditCosts)(a, b)
+}
+
+// OSA returns the optimal string alignment distance between the runes of
+// a and b, using DefaultEditCosts and normalized to [0.0..1.0] as
+// Levenshein is. Unlike DamerauLevenshtein, a substring may only be
+// edited once, so "CA" to "ABC" is not a transposition and an insertion.
+func OSA(a, b string) float64 {
+	return NewOSA(DefaultEditCosts)(a, b)
+}
+
+// NewDamerauLevenshtein returns a DamerauLevenshtein comparer using the
+// edit costs c, which must have 2*c.Transpose >= c.Insert+c.Delete.
+func NewDamerauLevenshtein(c EditCosts) Comparer {
+	return func(a, b string) float64 {
+		ra, rb := Runes(a, b)
+		return c.normalize(damerauLevenshtein(ra, rb, c), ra, rb)
+	}
+}
+
+// NewOSA returns an OSA comparer using the edit costs c.
+func NewOSA(c EditCosts) Comparer {
+	return func(a, b string) float64 {
+		ra, rb := Runes(a, b)
+		return c.normalize(osa(ra, rb, c), ra, rb)
+	}
+}
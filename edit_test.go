@@ -0,0 +1,122 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/charles-haynes/strsim"
+)
+
+var editSims = map[string]strsim.Comparer{
+	"damerau-levenshtein": strsim.DamerauLevenshtein,
+	"osa":                 strsim.OSA,
+}
+
+func TestEditCompare(t *testing.T) {
+	for s, f := range editSims {
+		v := Values["equal"]
+		if r := f(v[0], v[1]); r != 1.0 {
+			t.Errorf("%s(%s,%s) = %5.3f, expected 1.0", s, v[0], v[1], r)
+		}
+		v = Values["almost equal"]
+		ae := f(v[0], v[1])
+		if ae >= 1.0 {
+			t.Errorf("%s(%s,%s) = %5.3f, expected < 1.0", s, v[0], v[1], ae)
+		}
+		v = Values["mostly unequal"]
+		if r := f(v[0], v[1]); r > ae || r < 0.0 {
+			t.Errorf("%s(%s,%s) = %5.3f, expected in [0.0..%5.3f]",
+				s, v[0], v[1], r, ae)
+		}
+		for _, n := range GroupsEqual {
+			if r, e := f(n[0], n[1]), f(n[1], n[0]); r != e {
+				t.Errorf("%s(%s,%s) = %5.3f, expected %5.3f as reversed",
+					s, n[0], n[1], r, e)
+			}
+		}
+	}
+}
+
+func BenchmarkEdit(b *testing.B) {
+	for s, f := range editSims {
+		for c, v := range Values {
+			b.Run(s+": "+c, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					_ = f(v[0], v[1])
+				}
+			})
+		}
+	}
+}
+
+func TestEditDistances(t *testing.T) {
+	unit := strsim.EditCosts{Insert: 1, Delete: 1, Substitute: 1,
+		Transpose: 1}
+	for _, c := range []struct {
+		a, b    string
+		dl, osa float64
+		f, g    strsim.Comparer
+	}{
+		{"Jakc", "Jack", 1 - 1.0/8, 1 - 1.0/8,
+			strsim.DamerauLevenshtein, strsim.OSA},
+		{"CA", "ABC", 1 - 2.0/5, 1 - 3.0/5,
+			strsim.DamerauLevenshtein, strsim.OSA},
+		{"Ahmedou Ahmed Lewla", "Ahmedou Ahmed Lowla", 1 - 2.0/38, 1 - 2.0/38,
+			strsim.DamerauLevenshtein, strsim.OSA},
+		{"Stéphane", "Stpéhane", 1 - 1.0/16, 1 - 1.0/16,
+			strsim.DamerauLevenshtein, strsim.OSA},
+		{"", "", 1.0, 1.0,
+			strsim.DamerauLevenshtein, strsim.OSA},
+		{"Jakc", "Jack", 1 - 1.0/8, 1 - 1.0/8,
+			strsim.NewDamerauLevenshtein(unit), strsim.NewOSA(unit)},
+		{"abc", "xyz", 1 - 3.0/6, 1 - 3.0/6,
+			strsim.NewDamerauLevenshtein(unit), strsim.NewOSA(unit)},
+		{"CA", "ABC", 1 - 2.0/5, 1 - 3.0/5,
+			strsim.NewDamerauLevenshtein(unit), strsim.NewOSA(unit)},
+	} {
+		if r := c.f(c.a, c.b); math.Abs(r-c.dl) > 1e-9 {
+			t.Errorf("DamerauLevenshtein(%s,%s) = %5.3f, expected %5.3f",
+				c.a, c.b, r, c.dl)
+		}
+		if r := c.g(c.a, c.b); math.Abs(r-c.osa) > 1e-9 {
+			t.Errorf("OSA(%s,%s) = %5.3f, expected %5.3f",
+				c.a, c.b, r, c.osa)
+		}
+	}
+	if r := strsim.Levenshein("", ""); r != 1.0 {
+		t.Errorf("Levenshein(,) = %5.3f, expected 1.0", r)
+	}
+	// without transpositions both agree with Levenshein
+	for _, n := range GroupsEqual {
+		l := strsim.Levenshein(n[0], n[1])
+		no := strsim.EditCosts{Insert: 1, Delete: 1, Substitute: 2,
+			Transpose: 100}
+		if r := strsim.NewOSA(no)(n[0], n[1]); r != l {
+			t.Errorf("OSA(%s,%s) = %5.3f, expected %5.3f", n[0], n[1], r, l)
+		}
+		if r := strsim.NewDamerauLevenshtein(no)(n[0], n[1]); r != l {
+			t.Errorf("DamerauLevenshtein(%s,%s) = %5.3f, expected %5.3f",
+				n[0], n[1], r, l)
+		}
+	}
+}
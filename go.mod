@@ -5,5 +5,7 @@ go 1.13
 require (
 	github.com/jmoiron/sqlx v1.2.0
 	github.com/mattn/go-sqlite3 v1.11.0
+	github.com/rivo/uniseg v0.1.0
 	github.com/xrash/smetrics v0.0.0-20170218160415-a3153f7040e9
+	golang.org/x/text v0.3.2
 )
@@ -0,0 +1,674 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim
+
+// sam is a suffix automaton, the smallest automaton that accepts all the
+// suffixes of a string. Every substring of the string is the label of a
+// path from the root, state 0.
+type sam struct {
+	states []samState
+	edges  []samEdge
+	last   int
+}
+
+type samState struct {
+	// len is the length of the longest substring ending in this state
+	len int
+	// link is the state of the longest suffix of that substring that is
+	// in a different state
+	link int
+	// first is the end position of the first occurrence of the
+	// substrings in this state
+	first int
+	// edge is the index of the first of this state's edges, or -1
+	edge int
+}
+
+type samEdge struct {
+	r    rune
+	to   int
+	next int
+}
+
+// reset makes s the automaton for the empty string, keeping its storage.
+func (s *sam) reset() {
+	s.states = append(s.states[:0], samState{link: -1, first: -1, edge: -1})
+	s.edges = s.edges[:0]
+	s.last = 0
+}
+
+// get returns the state reached from state v by r, or -1.
+func (s *sam) get(v int, r rune) int {
+	for e := s.states[v].edge; e >= 0; e = s.edges[e].next {
+		if s.edges[e].r == r {
+			return s.edges[e].to
+		}
+	}
+	return -1
+}
+
+// set makes r lead from state v to state to.
+func (s *sam) set(v int, r rune, to int) {
+	for e := s.states[v].edge; e >= 0; e = s.edges[e].next {
+		if s.edges[e].r == r {
+			s.edges[e].to = to
+			return
+		}
+	}
+	s.edges = append(s.edges, samEdge{r, to, s.states[v].edge})
+	s.states[v].edge = len(s.edges) - 1
+}
+
+// extend appends r, at position pos, to the strings s accepts.
+func (s *sam) extend(r rune, pos int) {
+	if q := s.get(s.last, r); q >= 0 {
+		// the new string is already a substring of one s accepts
+		if s.states[s.last].len+1 == s.states[q].len {
+			s.last = q
+			return
+		}
+		s.last = s.split(s.last, r, q)
+		return
+	}
+	cur := len(s.states)
+	s.states = append(s.states, samState{
+		len: s.states[s.last].len + 1, first: pos, edge: -1})
+	p := s.last
+	for ; p >= 0 && s.get(p, r) < 0; p = s.states[p].link {
+		s.set(p, r, cur)
+	}
+	s.last = cur
+	if p < 0 {
+		s.states[cur].link = 0
+		return
+	}
+	q := s.get(p, r)
+	if s.states[p].len+1 == s.states[q].len {
+		s.states[cur].link = q
+		return
+	}
+	s.states[cur].link = s.split(p, r, q)
+}
+
+// split moves the substrings of state q, which is reached from state p by
+// r, that are no longer than p's longest plus r into a new state, and
+// returns it.
+func (s *sam) split(p int, r rune, q int) int {
+	clone := len(s.states)
+	s.states = append(s.states, samState{
+		len:   s.states[p].len + 1,
+		link:  s.states[q].link,
+		first: s.states[q].first,
+		edge:  -1,
+	})
+	for e := s.states[q].edge; e >= 0; e = s.edges[e].next {
+		s.set(clone, s.edges[e].r, s.edges[e].to)
+	}
+	for ; p >= 0 && s.get(p, r) == q; p = s.states[p].link {
+		s.set(p, r, clone)
+	}
+	s.states[q].link = clone
+	return clone
+}
+
+// build makes s the automaton for the substrings of b that don't span any
+// of the masked runes, those that are negative.
+func (s *sam) build(b []rune) {
+	s.reset()
+	for j, r := range b {
+		if r < 0 {
+			s.last = 0
+			continue
+		}
+		s.extend(r, j)
+	}
+}
+
+// longest returns the length of the longest common substring of a and the
+// string s accepts, with the positions of its last runes in each. Of the
+// longest it returns the one that ends first in a, and then in b.
+func (s *sam) longest(a []rune) (length, aEnd, bEnd int) {
+	v, l := 0, 0
+	for i, r := range a {
+		for v > 0 && s.get(v, r) < 0 {
+			v = s.states[v].link
+			l = s.states[v].len
+		}
+		if t := s.get(v, r); t >= 0 {
+			v = t
+			l++
+		} else {
+			v, l = 0, 0
+		}
+		if l > length {
+			length, aEnd, bEnd = l, i, s.states[v].first
+		}
+	}
+	return length, aEnd, bEnd
+}
+
+// forTiles calls f with the length and the start in a and in b of each
+// common substring of a and b that is at least min runes long, longest
+// first. The runes of a substring that has been found are masked, so that
+// later substrings can't overlap or span them. Ties are broken by the
+// earliest start in a, then in b.
+//
+// It works on a suffix array of a and b. The suffixes that share a prefix
+// of some length form intervals of the array, which merge as the length
+// decreases. Working down from the longest possible length, a substring of
+// that length is common if an interval has a suffix of a and a suffix of b
+// that both have that many runes before the next mask, so finding all the
+// substrings takes O((n+m) log(n+m)).
+func forTiles(a, b []rune, min int, f func(length, aStart, bStart int)) {
+	max := len(a)
+	if len(b) < max {
+		max = len(b)
+	}
+	if min < 1 {
+		min = 1
+	}
+	if max < min {
+		return
+	}
+	t := newTiler(a, b, min, max)
+	for l := max; l >= min; l-- {
+		for k := t.mergeHead[l]; k >= 0; k = t.mergeNext[k] {
+			t.union(k-1, k)
+			t.push(k)
+		}
+		for e := t.readyHead[l]; e >= 0; e = t.ready[e].next {
+			t.use(t.ready[e].p, l)
+		}
+		for len(t.heap) > 0 {
+			r, key := t.pop()
+			if t.find(r) != r || t.queued[r] != key {
+				// merged into another group, or queued again since
+				continue
+			}
+			t.queued[r] = -1
+			i := t.inA.min(t.lo[r], t.hi[r])
+			j := t.inB.min(t.lo[r], t.hi[r])
+			if i >= t.n || j >= t.n {
+				continue
+			}
+			if i != key {
+				t.queue(r, i)
+				continue
+			}
+			f(l, i, j-len(a)-1)
+			t.mask(i, i+l-1, l)
+			t.mask(j, j+l-1, l)
+			t.push(r)
+		}
+	}
+}
+
+// tiler is the state of forTiles. Positions are in t, which is a and b
+// separated by -1. Groups are the intervals of the suffix array whose
+// suffixes share a prefix of the current length.
+type tiler struct {
+	t        []rune
+	n, la    int
+	min, max int
+	sa, rank []int
+	// the k whose suffix shares l runes with suffix k-1, by l
+	mergeHead, mergeNext []int
+	// rem is the number of runes from each position to the next mask or
+	// the end of its string. A position is usable once the length is down
+	// to its rem, and the ready entries list them by that length.
+	rem       []int
+	readyHead []int
+	ready     []readyEntry
+	// state is whether each position is masked or usable
+	state []byte
+	// the least usable position of a and of b in a range of ranks
+	inA, inB minTree
+	// the union-find of groups by rank. Roots have the bounds of their
+	// interval, and the key they are queued on the heap with or -1.
+	parent, lo, hi, queued []int
+	heap                   []groupEntry
+}
+
+type readyEntry struct {
+	p, next int
+}
+
+type groupEntry struct {
+	key, root int
+}
+
+const (
+	tileMasked = 1 + iota
+	tileUsable
+)
+
+func newTiler(a, b []rune, min, max int) *tiler {
+	n := len(a) + 1 + len(b)
+	tr := &tiler{n: n, la: len(a), min: min, max: max}
+	tr.t = make([]rune, 0, n)
+	tr.t = append(append(append(tr.t, a...), -1), b...)
+	ints := make([]int, 10*n+2*(max+1))
+	next := func(k int) []int {
+		s := ints[:k:k]
+		ints = ints[k:]
+		return s
+	}
+	tr.sa, tr.rank = next(n), next(n)
+	suffixArray(tr.t, tr.sa, tr.rank, next(n), next(n))
+	tr.mergeHead, tr.mergeNext = next(max+1), next(n)
+	tr.readyHead, tr.rem = next(max+1), next(n)
+	tr.parent, tr.lo, tr.hi, tr.queued = next(n), next(n), next(n), next(n)
+	for l := range tr.mergeHead {
+		tr.mergeHead[l], tr.readyHead[l] = -1, -1
+	}
+	// the lcp array goes where parent will be
+	for k, l := range lcpArray(tr.t, tr.sa, tr.rank, tr.parent) {
+		tr.mergeNext[k] = -1
+		if l >= min {
+			if l > max {
+				l = max
+			}
+			tr.mergeNext[k], tr.mergeHead[l] = tr.mergeHead[l], k
+		}
+	}
+	tr.ready = make([]readyEntry, 0, 2*n)
+	for p := range tr.t {
+		switch {
+		case p < len(a):
+			tr.setRem(p, len(a)-p)
+		case p > len(a):
+			tr.setRem(p, n-p)
+		}
+		tr.parent[p], tr.lo[p], tr.hi[p], tr.queued[p] = p, p, p, -1
+	}
+	tr.state = make([]byte, n)
+	tr.inA, tr.inB = newMinTree(n), newMinTree(n)
+	return tr
+}
+
+// setRem sets the rem of p, and lists when it will be usable.
+func (tr *tiler) setRem(p, l int) {
+	tr.rem[p] = l
+	if l > tr.max {
+		l = tr.max
+	}
+	if l >= tr.min {
+		tr.ready = append(tr.ready, readyEntry{p, tr.readyHead[l]})
+		tr.readyHead[l] = len(tr.ready) - 1
+	}
+}
+
+// use makes p usable for substrings of length l.
+func (tr *tiler) use(p, l int) {
+	if tr.state[p] != 0 || tr.rem[p] != l && tr.rem[p] <= tr.max {
+		// a stale entry, p has since been masked or is nearer a mask
+		return
+	}
+	tr.state[p] = tileUsable
+	if p < tr.la {
+		tr.inA.set(tr.rank[p], p)
+	} else {
+		tr.inB.set(tr.rank[p], p)
+	}
+	tr.push(tr.rank[p])
+}
+
+// unuse makes p unusable.
+func (tr *tiler) unuse(p int) {
+	if tr.state[p] == tileUsable {
+		tr.inA.set(tr.rank[p], tr.n)
+		tr.inB.set(tr.rank[p], tr.n)
+	}
+	tr.state[p] = 0
+}
+
+// mask masks the runes from s to e, and updates the rem of the runes
+// before them that are now less than l from a mask.
+func (tr *tiler) mask(s, e, l int) {
+	for p := s; p <= e; p++ {
+		tr.unuse(p)
+		tr.state[p] = tileMasked
+		tr.rem[p] = 0
+	}
+	for p := s - 1; p > s-l && p >= 0 && tr.state[p] != tileMasked &&
+		tr.t[p] >= 0; p-- {
+		tr.unuse(p)
+		tr.setRem(p, s-p)
+	}
+}
+
+// push queues the group of rank k, if it has a usable position of a.
+func (tr *tiler) push(k int) {
+	r := tr.find(k)
+	if i := tr.inA.min(tr.lo[r], tr.hi[r]); i < tr.n {
+		tr.queue(r, i)
+	}
+}
+
+// queue queues group r with key, the least usable position of a it may
+// have, unless it is already queued with a lower key.
+func (tr *tiler) queue(r, key int) {
+	if tr.queued[r] >= 0 && tr.queued[r] <= key {
+		return
+	}
+	tr.queued[r] = key
+	tr.heap = append(tr.heap, groupEntry{key, r})
+	for i := len(tr.heap) - 1; i > 0; {
+		p := (i - 1) / 2
+		if tr.heap[p].key <= tr.heap[i].key {
+			break
+		}
+		tr.heap[p], tr.heap[i] = tr.heap[i], tr.heap[p]
+		i = p
+	}
+}
+
+// pop removes the group with the least key from the heap.
+func (tr *tiler) pop() (root, key int) {
+	h := tr.heap
+	e := h[0]
+	h[0] = h[len(h)-1]
+	h = h[:len(h)-1]
+	for i := 0; ; {
+		c := 2*i + 1
+		if c >= len(h) {
+			break
+		}
+		if c+1 < len(h) && h[c+1].key < h[c].key {
+			c++
+		}
+		if h[i].key <= h[c].key {
+			break
+		}
+		h[i], h[c] = h[c], h[i]
+		i = c
+	}
+	tr.heap = h
+	return e.root, e.key
+}
+
+func (tr *tiler) find(k int) int {
+	for tr.parent[k] != k {
+		tr.parent[k] = tr.parent[tr.parent[k]]
+		k = tr.parent[k]
+	}
+	return k
+}
+
+// union joins the adjacent groups of ranks i and j.
+func (tr *tiler) union(i, j int) {
+	i, j = tr.find(i), tr.find(j)
+	if tr.hi[i]-tr.lo[i] < tr.hi[j]-tr.lo[j] {
+		i, j = j, i
+	}
+	tr.parent[j] = i
+	if tr.lo[j] < tr.lo[i] {
+		tr.lo[i] = tr.lo[j]
+	}
+	if tr.hi[j] > tr.hi[i] {
+		tr.hi[i] = tr.hi[j]
+	}
+}
+
+// suffixArray sets sa to the start positions of the suffixes of t in
+// order, and rank to the position of each suffix in sa, by prefix doubling.
+// tmp and next are scratch space as long as t.
+func suffixArray(t []rune, sa, rank, tmp, next []int) {
+	n := len(t)
+	// radix sort the positions by rune, a byte at a time
+	for i := range sa {
+		sa[i] = i
+	}
+	var count [257]int
+	for shift := uint(0); shift < 32; shift += 8 {
+		count = [257]int{}
+		for _, i := range sa {
+			count[(uint32(t[i])+1)>>shift&0xff+1]++
+		}
+		if count[(uint32(t[0])+1)>>shift&0xff+1] == n {
+			continue
+		}
+		for c := 1; c < len(count); c++ {
+			count[c] += count[c-1]
+		}
+		for _, i := range sa {
+			c := (uint32(t[i]) + 1) >> shift & 0xff
+			tmp[count[c]] = i
+			count[c]++
+		}
+		copy(sa, tmp)
+	}
+	r := 0
+	for k, i := range sa {
+		if k > 0 && t[i] != t[sa[k-1]] {
+			r++
+		}
+		rank[i] = r
+	}
+	for h := 1; r < n-1; h *= 2 {
+		// sort by the rank of the suffix h on, then stably by rank
+		k := 0
+		for i := n - h; i < n; i++ {
+			tmp[k] = i
+			k++
+		}
+		for _, i := range sa {
+			if i >= h {
+				tmp[k] = i - h
+				k++
+			}
+		}
+		count := next[:r+2]
+		for i := range count {
+			count[i] = 0
+		}
+		for _, i := range tmp {
+			count[rank[i]+1]++
+		}
+		for i := 1; i < len(count); i++ {
+			count[i] += count[i-1]
+		}
+		for _, i := range tmp {
+			sa[count[rank[i]]] = i
+			count[rank[i]]++
+		}
+		second := func(i int) int {
+			if i+h < n {
+				return rank[i+h]
+			}
+			return -1
+		}
+		r = 0
+		next[sa[0]] = 0
+		for k := 1; k < n; k++ {
+			i, j := sa[k], sa[k-1]
+			if rank[i] != rank[j] || second(i) != second(j) {
+				r++
+			}
+			next[i] = r
+		}
+		copy(rank, next)
+	}
+}
+
+// lcpArray sets lcp to the length of the common prefix of each suffix in
+// sa and the one before it, using Kasai's algorithm, and returns it.
+func lcpArray(t []rune, sa, rank, lcp []int) []int {
+	h := 0
+	for i := range t {
+		if rank[i] == 0 {
+			lcp[0], h = 0, 0
+			continue
+		}
+		j := sa[rank[i]-1]
+		for i+h < len(t) && j+h < len(t) && t[i+h] == t[j+h] {
+			h++
+		}
+		lcp[rank[i]] = h
+		if h > 0 {
+			h--
+		}
+	}
+	return lcp
+}
+
+// minTree is a segment tree of the least of n ints, initially n.
+type minTree struct {
+	size, none int
+	t          []int
+}
+
+func newMinTree(n int) minTree {
+	size := 1
+	for size < n {
+		size *= 2
+	}
+	m := minTree{size, n, make([]int, 2*size)}
+	for i := range m.t {
+		m.t[i] = n
+	}
+	return m
+}
+
+func (m minTree) set(i, v int) {
+	i += m.size
+	m.t[i] = v
+	for i /= 2; i > 0; i /= 2 {
+		m.t[i] = m.t[2*i]
+		if m.t[2*i+1] < m.t[i] {
+			m.t[i] = m.t[2*i+1]
+		}
+	}
+}
+
+// min returns the least of the ints from lo to hi.
+func (m minTree) min(lo, hi int) int {
+	r := m.none
+	for lo, hi = lo+m.size, hi+m.size+1; lo < hi; lo, hi = lo/2, hi/2 {
+		if lo&1 == 1 {
+			if m.t[lo] < r {
+				r = m.t[lo]
+			}
+			lo++
+		}
+		if hi&1 == 1 {
+			hi--
+			if m.t[hi] < r {
+				r = m.t[hi]
+			}
+		}
+	}
+	return r
+}
+
+// orderedLen returns the total length of the common substrings of a and b
+// that are at least min runes long, found by taking the longest and then
+// recursing on the runes to its left and to its right, so that the
+// substrings are in the same order in both.
+func orderedLen(a, b []rune, min int, s *sam) int {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	s.build(b)
+	l, aEnd, bEnd := s.longest(a)
+	if l < min || l == 0 {
+		return 0
+	}
+	return l + orderedLen(a[:aEnd+1-l], b[:bEnd+1-l], min, s) +
+		orderedLen(a[aEnd+1:], b[bEnd+1:], min, s)
+}
+
+// Ratio selects how the length common to two strings is turned into a
+// score.
+type Ratio int
+
+const (
+	// JaccardRatio is the common length over the length of the union,
+	// as LCS uses.
+	JaccardRatio Ratio = iota
+	// DiceRatio is twice the common length over the total length.
+	DiceRatio
+	// MinLengthRatio is the common length over the length of the shorter
+	// string, so that a string scores 1.0 against anything containing
+	// it.
+	MinLengthRatio
+)
+
+// score returns the ratio of a common length s of strings of lengths la
+// and lb. Two empty strings score 1.0, and an empty string scores 0.0
+// against any other.
+func (r Ratio) score(s, la, lb int) float64 {
+	if la == 0 || lb == 0 {
+		if la == lb {
+			return 1.0
+		}
+		return 0.0
+	}
+	switch r {
+	case DiceRatio:
+		return 2 * float64(s) / float64(la+lb)
+	case MinLengthRatio:
+		if lb < la {
+			la = lb
+		}
+		return float64(s) / float64(la)
+	}
+	return float64(s) / float64(la+lb-s)
+}
+
+// LCSOptions configure a comparer returned by LCSWith.
+type LCSOptions struct {
+	// MinLength is the length of the shortest common substring counted.
+	// Strings shorter than it only match if they are equal. Zero means 3,
+	// as LCS uses.
+	MinLength int
+	// Ordered only counts common substrings that are in the same order
+	// in both strings, as Ratcliff/Obershelp does, rather than greedily
+	// tiling them as LCS does.
+	Ordered bool
+	// Ratio is how the length of the common substrings is turned into a
+	// score.
+	Ratio Ratio
+	// Segmenter splits the strings into units, nil means Runes.
+	Segmenter Segmenter
+}
+
+// LCSWith returns a comparer like LCS configured by o.
+func LCSWith(o LCSOptions) Comparer {
+	min := o.MinLength
+	if min <= 0 {
+		min = shortestSubStrLen
+	}
+	seg := o.Segmenter
+	if seg == nil {
+		seg = Runes
+	}
+	return func(a, b string) float64 {
+		ra, rb := seg(a, b)
+		var s int
+		if o.Ordered && len(ra) >= min && len(rb) >= min {
+			s = orderedLen(ra, rb, min, &sam{})
+		} else {
+			s = tiledLen(ra, rb, min)
+		}
+		return o.Ratio.score(s, len(ra), len(rb))
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/charles-haynes/strsim"
+)
+
+func TestLCSWith(t *testing.T) {
+	for _, n := range GroupsEqual {
+		e := strsim.LCS(n[0], n[1])
+		if r := strsim.LCSWith(strsim.LCSOptions{})(n[0], n[1]); r != e {
+			t.Errorf("LCSWith(%s,%s) = %5.3f, expected %5.3f",
+				n[0], n[1], r, e)
+		}
+	}
+	for _, c := range []struct {
+		o    strsim.LCSOptions
+		a, b string
+		e    float64
+	}{
+		{strsim.LCSOptions{}, "abcdXefgh", "efghYabcd", 8.0 / 10},
+		{strsim.LCSOptions{Ordered: true}, "abcdXefgh", "efghYabcd", 4.0 / 14},
+		{strsim.LCSOptions{Ratio: strsim.DiceRatio}, "abcdXefgh", "efghYabcd", 16.0 / 18},
+		{strsim.LCSOptions{Ratio: strsim.MinLengthRatio}, "FRKWYS", "FRKWYS Vol. 15", 1.0},
+		{strsim.LCSOptions{MinLength: 5}, "abcdXefgh", "efghYabcd", 0.0},
+		{strsim.LCSOptions{MinLength: 1}, "ab", "ba", 1.0},
+		{strsim.LCSOptions{MinLength: 1, Ordered: true}, "ab", "ba", 1.0 / 3},
+		{strsim.LCSOptions{MinLength: 4}, "abc", "abc", 1.0},
+		{strsim.LCSOptions{Segmenter: strsim.Graphemes}, "Stéphane", "Stéphane", 1.0},
+	} {
+		if r := strsim.LCSWith(c.o)(c.a, c.b); math.Abs(r-c.e) > 1e-9 {
+			t.Errorf("LCSWith(%+v)(%s,%s) = %5.3f, expected %5.3f",
+				c.o, c.a, c.b, r, c.e)
+		}
+	}
+}
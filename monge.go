@@ -0,0 +1,67 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim
+
+// MongeElkan returns a comparer that tokenizes a and b and averages, over
+// the tokens of a, the best score from inner of that token against the
+// tokens of b. It is not symmetric: a scores 1.0 against any b containing
+// all its tokens.
+func MongeElkan(inner Comparer) Comparer {
+	return func(a, b string) float64 {
+		return mongeElkan(Tokens(a), Tokens(b), inner)
+	}
+}
+
+// SymmetricMongeElkan returns a comparer of the mean of the MongeElkan
+// scores of a against b and b against a.
+func SymmetricMongeElkan(inner Comparer) Comparer {
+	return func(a, b string) float64 {
+		ta, tb := Tokens(a), Tokens(b)
+		return (mongeElkan(ta, tb, inner) + mongeElkan(tb, ta, inner)) / 2
+	}
+}
+
+func mongeElkan(as, bs []string, inner Comparer) float64 {
+	return mongeElkanBy(len(as), len(bs), func(i, j int) float64 {
+		return inner(as[i], bs[j])
+	})
+}
+
+// mongeElkanBy averages, over i < na, the best score(i, j) for j < nb.
+func mongeElkanBy(na, nb int, score func(i, j int) float64) float64 {
+	if na == 0 || nb == 0 {
+		if na == nb {
+			return 1.0
+		}
+		return 0.0
+	}
+	sum := 0.0
+	for i := 0; i < na; i++ {
+		max := 0.0
+		for j := 0; j < nb; j++ {
+			if m := score(i, j); m > max {
+				max = m
+			}
+		}
+		sum += max
+	}
+	return sum / float64(na)
+}
@@ -0,0 +1,62 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/charles-haynes/strsim"
+)
+
+func TestMongeElkan(t *testing.T) {
+	week := "Bob Stanley & Pete Wiggs Present Three Day Week " +
+		"(When The Lights Went Out 1972-1975)"
+	for _, c := range []struct {
+		name string
+		f    strsim.Comparer
+		a, b string
+		e    float64
+	}{
+		{"monge-elkan", strsim.MongeElkan(strsim.StringCompare),
+			"a b c", "A B", 2.0 / 3},
+		{"monge-elkan", strsim.MongeElkan(strsim.StringCompare),
+			"a b", "A B C", 1.0},
+		{"monge-elkan", strsim.MongeElkan(strsim.StringCompare), "", "", 1.0},
+		{"monge-elkan", strsim.MongeElkan(strsim.StringCompare), "", "a", 0.0},
+		{"monge-elkan", strsim.MongeElkan(strsim.Levenshein),
+			"Three Day Week: When The Lights Went Out 1972-1975", week, 1.0},
+		{"monge-elkan", strsim.MongeElkan(strsim.Levenshein),
+			"Jon Hopkins", "John Hopkins", (1.0 - 1.0/7 + 1) / 2},
+		{"symmetric monge-elkan",
+			strsim.SymmetricMongeElkan(strsim.StringCompare),
+			"a b c", "A B", 5.0 / 6},
+		{"symmetric monge-elkan",
+			strsim.SymmetricMongeElkan(strsim.StringCompare),
+			week, "Three Day Week: When The Lights Went Out 1972-1975",
+			(1.0 + 10.0/15) / 2},
+	} {
+		if r := c.f(c.a, c.b); math.Abs(r-c.e) > 1e-9 {
+			t.Errorf("%s(%s,%s) = %5.3f, expected %5.3f",
+				c.name, c.a, c.b, r, c.e)
+		}
+	}
+}
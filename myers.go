@@ -0,0 +1,245 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim
+
+import (
+	"math/bits"
+)
+
+// peq holds, for each rune of a pattern, the bit vector of the positions
+// in the pattern where it occurs, split into 64 bit words.
+type peq struct {
+	words int
+	ascii []uint64
+	other map[rune][]uint64
+}
+
+func newPeq(p []rune) peq {
+	q := peq{words: (len(p) + 63) / 64}
+	q.ascii = make([]uint64, 128*q.words)
+	for i, r := range p {
+		w, bit := i/64, uint64(1)<<uint(i%64)
+		if r >= 0 && r < 128 {
+			q.ascii[int(r)*q.words+w] |= bit
+			continue
+		}
+		if q.other == nil {
+			q.other = map[rune][]uint64{}
+		}
+		v, ok := q.other[r]
+		if !ok {
+			v = make([]uint64, q.words)
+			q.other[r] = v
+		}
+		v[w] |= bit
+	}
+	return q
+}
+
+// get returns the bit vector for r, or nil if r is not in the pattern.
+func (q peq) get(r rune) []uint64 {
+	if r >= 0 && r < 128 {
+		return q.ascii[int(r)*q.words : int(r+1)*q.words]
+	}
+	return q.other[r]
+}
+
+// shorter returns a and b with the shorter of them first.
+func shorter(a, b []rune) ([]rune, []rune) {
+	if len(b) < len(a) {
+		return b, a
+	}
+	return a, b
+}
+
+// bitLCS returns the length of the longest common subsequence of a and b
+// using Hyyrö's bit-parallel algorithm, in O(len(a)·len(b)/64) time.
+func bitLCS(a, b []rune) int {
+	p, t := shorter(a, b)
+	if len(p) == 0 {
+		return 0
+	}
+	if len(p) <= 64 {
+		return bitLCS64(p, t)
+	}
+	q := newPeq(p)
+	v := make([]uint64, q.words)
+	for i := range v {
+		v[i] = ^uint64(0)
+	}
+	for _, r := range t {
+		m := q.get(r)
+		if m == nil {
+			continue
+		}
+		var carry uint64
+		for k := range v {
+			u := v[k] & m[k]
+			var sum uint64
+			sum, carry = bits.Add64(v[k], u, carry)
+			v[k] = sum | (v[k] - u)
+		}
+	}
+	n := 0
+	for k, x := range v {
+		if k == len(v)-1 && len(p)%64 != 0 {
+			x |= ^uint64(0) << uint(len(p)%64)
+		}
+		n += 64 - bits.OnesCount64(x)
+	}
+	return n
+}
+
+// peq64 is a peq for patterns of at most 64 runes that doesn't allocate
+// for ASCII patterns.
+type peq64 struct {
+	ascii [128]uint64
+	other map[rune]uint64
+}
+
+func (q *peq64) init(p []rune) {
+	for i, r := range p {
+		if r >= 0 && r < 128 {
+			q.ascii[r] |= 1 << uint(i)
+			continue
+		}
+		if q.other == nil {
+			q.other = map[rune]uint64{}
+		}
+		q.other[r] |= 1 << uint(i)
+	}
+}
+
+func (q *peq64) get(r rune) uint64 {
+	if r >= 0 && r < 128 {
+		return q.ascii[r]
+	}
+	return q.other[r]
+}
+
+// bitLCS64 is bitLCS for a pattern p of at most 64 runes.
+func bitLCS64(p, t []rune) int {
+	var q peq64
+	q.init(p)
+	v := ^uint64(0)
+	for _, r := range t {
+		u := v & q.get(r)
+		v = (v + u) | (v - u)
+	}
+	return bits.OnesCount64(^v << uint(64-len(p)))
+}
+
+// myers64 is myers for a pattern p of at most 64 runes.
+func myers64(p, t []rune) int {
+	var q peq64
+	q.init(p)
+	pv, mv := ^uint64(0), uint64(0)
+	high := uint64(1) << uint(len(p)-1)
+	score := len(p)
+	for _, r := range t {
+		score += advanceBlock(&pv, &mv, q.get(r), 1, high)
+	}
+	return score
+}
+
+// myers returns the Levenshtein distance, with unit costs, between a and
+// b using Myers' bit-parallel algorithm with Hyyrö's extension to
+// patterns of more than 64 runes, in O(len(a)·len(b)/64) time.
+func myers(a, b []rune) int {
+	p, t := shorter(a, b)
+	if len(p) == 0 {
+		return len(t)
+	}
+	if len(p) <= 64 {
+		return myers64(p, t)
+	}
+	q := newPeq(p)
+	pv := make([]uint64, q.words)
+	mv := make([]uint64, q.words)
+	for i := range pv {
+		pv[i] = ^uint64(0)
+	}
+	last := uint64(1) << uint((len(p)-1)%64)
+	score := len(p)
+	for _, r := range t {
+		eqs := q.get(r)
+		hin := 1
+		for k := range pv {
+			var eq uint64
+			if eqs != nil {
+				eq = eqs[k]
+			}
+			high := uint64(1) << 63
+			if k == len(pv)-1 {
+				high = last
+			}
+			hin = advanceBlock(&pv[k], &mv[k], eq, hin, high)
+		}
+		score += hin
+	}
+	return score
+}
+
+// advanceBlock advances one 64 row block of the Myers distance matrix by
+// a column, given the vertical deltas pv, mv, the match vector eq and the
+// horizontal delta hin into the top of the block. It returns the
+// horizontal delta out of the bit high.
+func advanceBlock(pv, mv *uint64, eq uint64, hin int, high uint64) int {
+	p, m := *pv, *mv
+	xv := eq | m
+	if hin < 0 {
+		eq |= 1
+	}
+	xh := (((eq & p) + p) ^ p) | eq
+	ph := m | ^(xh | p)
+	mh := p & xh
+	hout := 0
+	if ph&high != 0 {
+		hout = 1
+	} else if mh&high != 0 {
+		hout = -1
+	}
+	ph <<= 1
+	mh <<= 1
+	if hin < 0 {
+		mh |= 1
+	} else if hin > 0 {
+		ph |= 1
+	}
+	*pv = mh | ^(xv | ph)
+	*mv = ph & xv
+	return hout
+}
+
+// UnitLevenshtein returns the Levenshtein distance between the runes of a
+// and b, counting every edit as 1, normalized by the length of the longer
+// of them. It uses Myers' bit-parallel algorithm.
+func UnitLevenshtein(a, b string) float64 {
+	ra, rb := Runes(a, b)
+	l := len(ra)
+	if len(rb) > l {
+		l = len(rb)
+	}
+	if l == 0 {
+		return 1.0
+	}
+	return 1.0 - float64(myers(ra, rb))/float64(l)
+}
@@ -0,0 +1,129 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// Articles are the lower cased leading articles removed by
+	// StripArticles. Elided articles end in an apostrophe.
+	Articles = []string{"the", "a", "an", "l'"}
+
+	// ForeignArticles are the lower cased leading articles other than
+	// Articles that StripAllArticles also removes. StripArticles leaves
+	// them, as they start many English names, such as "La Roux" or
+	// "Lo Fidelity Allstars".
+	ForeignArticles = []string{
+		"le", "la", "les", "die", "der", "das", "den", "el", "los",
+		"las", "il", "lo", "gli", "het",
+	}
+
+	// Honorifics are the lower cased titles removed by StripHonorifics.
+	Honorifics = []string{
+		"sir", "dame", "lord", "lady", "dr", "dr.", "prof", "prof.", "mr",
+		"mr.", "mrs", "mrs.", "ms", "ms.", "dj", "mc",
+	}
+
+	// StripArticles removes a leading article, as in "The Mekons" or
+	// "L'Orchestre Afrisa", or a trailing one, as in "Beatles, The". It
+	// removes at most one, so "A La Carte" keeps "La".
+	StripArticles Normalizer = func(s string) string {
+		return stripPrefixes(s, true, true, Articles)
+	}
+
+	// StripAllArticles is StripArticles that also removes ForeignArticles,
+	// as in "Le Trio Joubran", for names that are mostly not English.
+	StripAllArticles Normalizer = func(s string) string {
+		return stripPrefixes(s, true, true, Articles, ForeignArticles)
+	}
+
+	// StripHonorifics removes leading honorifics, as in "Sir Roland Hanna"
+	// or "DJ Shadow".
+	StripHonorifics Normalizer = func(s string) string {
+		return stripPrefixes(s, false, false, Honorifics)
+	}
+
+	// StripName removes leading honorifics and an article, before or
+	// after them, from a name.
+	StripName Normalizer = func(s string) string {
+		if t := StripArticles(s); t != s {
+			return StripHonorifics(t)
+		}
+		return StripArticles(StripHonorifics(s))
+	}
+)
+
+// stripPrefixes removes the leading words of s that are in pss, and, if
+// trailing, a last word in pss preceded by a comma. If once, it removes at
+// most one word. It never removes the last word of s.
+func stripPrefixes(s string, trailing, once bool, pss ...[]string) string {
+	s = strings.TrimSpace(s)
+	if trailing {
+		if i := strings.LastIndex(s, ","); i > 0 &&
+			hasWord(pss, strings.TrimSpace(s[i+1:])) {
+			s = strings.TrimSpace(s[:i])
+			if once {
+				return s
+			}
+		}
+	}
+	for {
+		i := strings.IndexAny(s, " \t")
+		if i < 0 {
+			return s
+		}
+		w := s[:i]
+		if j := strings.IndexAny(w, "'’"); j >= 0 {
+			_, n := utf8.DecodeRuneInString(w[j:])
+			if j+n < len(w) && hasWord(pss, w[:j]+"'") {
+				return s[j+n:]
+			}
+		}
+		if !hasWord(pss, w) {
+			return s
+		}
+		s = strings.TrimSpace(s[i:])
+		if once {
+			return s
+		}
+	}
+}
+
+func hasWord(wss [][]string, w string) bool {
+	w = strings.ToLower(w)
+	for _, ws := range wss {
+		for _, x := range ws {
+			if w == x {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WrapName takes a comparer and returns a comparer that compares names
+// without their leading articles and honorifics.
+func WrapName(f Comparer) Comparer {
+	return Wrap(StripName, f)
+}
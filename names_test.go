@@ -0,0 +1,84 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim_test
+
+import (
+	"testing"
+
+	"github.com/charles-haynes/strsim"
+)
+
+func TestStripName(t *testing.T) {
+	for _, c := range []struct {
+		n    strsim.Normalizer
+		s, e string
+	}{
+		{strsim.StripArticles, "The Mekons", "Mekons"},
+		{strsim.StripArticles, "Le Trio Joubran", "Le Trio Joubran"},
+		{strsim.StripAllArticles, "Le Trio Joubran", "Trio Joubran"},
+		{strsim.StripArticles, "A La Carte", "La Carte"},
+		{strsim.StripAllArticles, "A La Carte", "La Carte"},
+		{strsim.StripArticles, "La Roux", "La Roux"},
+		{strsim.StripArticles, "Lo Fidelity Allstars", "Lo Fidelity Allstars"},
+		{strsim.StripArticles, "L’Orchestre Afrisa", "Orchestre Afrisa"},
+		{strsim.StripArticles, "Beatles, The", "Beatles"},
+		{strsim.StripArticles, "L'Orchestre Afrisa", "Orchestre Afrisa"},
+		{strsim.StripAllArticles, "Die Toten Hosen", "Toten Hosen"},
+		{strsim.StripArticles, "The The", "The"},
+		{strsim.StripArticles, "Theatre of Hate", "Theatre of Hate"},
+		{strsim.StripHonorifics, "Sir Roland Hanna", "Roland Hanna"},
+		{strsim.StripHonorifics, "Dr. Dre", "Dre"},
+		{strsim.StripHonorifics, "DJ Sagol", "Sagol"},
+		{strsim.StripHonorifics, "Dame", "Dame"},
+		{strsim.StripName, "The DJ Shadow", "Shadow"},
+		{strsim.StripName, "MC The Max", "Max"},
+		{strsim.StripName, "The A Team", "A Team"},
+		{strsim.StripName, "DJ La Roux", "La Roux"},
+	} {
+		if r := c.n(c.s); r != c.e {
+			t.Errorf("strip(%q) = %q, expected %q", c.s, r, c.e)
+		}
+	}
+}
+
+func TestWrapName(t *testing.T) {
+	f := strsim.WrapAliases(strsim.WrapName(strsim.WrapNoCase(
+		strsim.StringCompare)))
+	for _, n := range [][]string{
+		{"The Mekons", "Mekons"},
+		{"The Rossington Collins Band", "Rossington Collins Band"},
+		{"Sir Roland Hanna", "Roland Hanna"},
+		{"Lo Fidelity Allstars", "Lo Fidelity Allstars"},
+		{"The Master Musicians Of Jajouka", "Master Musicians of Jajouka"},
+	} {
+		if r := f(n[0], n[1]); r != 1.0 {
+			t.Errorf("(%s,%s) = %5.3f, expected 1.0", n[0], n[1], r)
+		}
+	}
+	if r := f("La Roux", "Roux"); r != 0.0 {
+		t.Errorf("(La Roux,Roux) = %5.3f, expected 0.0", r)
+	}
+	g := strsim.WrapAliases(strsim.Wrap(strsim.StripAllArticles,
+		strsim.WrapNoCase(strsim.StringCompare)))
+	if r := g("Trio Joubran", "Le Trio Joubran (الثلاثي جبران)"); r != 1.0 {
+		t.Errorf("(Trio Joubran,Le Trio Joubran) = %5.3f, expected 1.0", r)
+	}
+}
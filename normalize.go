@@ -0,0 +1,206 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalizer transforms a string before it is compared.
+type Normalizer = func(s string) string
+
+var (
+	// Lower lower cases s.
+	Lower Normalizer = strings.ToLower
+
+	// NFKC puts s in Unicode normalization form KC.
+	NFKC Normalizer = norm.NFKC.String
+
+	// FoldDiacritics removes accents and other combining marks, so that
+	// "Magnétiques" becomes "Magnetiques".
+	FoldDiacritics Normalizer = foldDiacritics
+
+	// FoldPunctuation replaces typographic quotes, apostrophes, dashes and
+	// ellipses with their ASCII equivalents.
+	FoldPunctuation Normalizer = punctuation.Replace
+
+	// CollapseSpace trims s and replaces each run of white space with a
+	// single space.
+	CollapseSpace Normalizer = collapseSpace
+
+	// StripZeroWidth removes zero width spaces, joiners and direction marks.
+	StripZeroWidth Normalizer = stripZeroWidth
+
+	// FoldCase applies Unicode full case folding, so that "Straße" and
+	// "STRASSE" are the same.
+	FoldCase = FoldCaseFor(language.Und)
+
+	// Standard applies all of the built in normalization steps.
+	Standard = Chain(NFKC, StripZeroWidth, FoldDiacritics, FoldPunctuation,
+		Lower, CollapseSpace)
+)
+
+// Normalizers are the built in normalization steps, by name.
+var Normalizers = map[string]Normalizer{
+	"lower":              Lower,
+	"nfkc":               NFKC,
+	"fold-diacritics":    FoldDiacritics,
+	"fold-punctuation":   FoldPunctuation,
+	"collapse-space":     CollapseSpace,
+	"strip-zero-width":   StripZeroWidth,
+	"fold-case":          FoldCase,
+	"strip-articles":     StripArticles,
+	"strip-all-articles": StripAllArticles,
+	"strip-honorifics":   StripHonorifics,
+}
+
+// Chain returns a Normalizer that applies each of ns in order.
+func Chain(ns ...Normalizer) Normalizer {
+	return func(s string) string {
+		for _, n := range ns {
+			s = n(s)
+		}
+		return s
+	}
+}
+
+// NewNormalizer returns a Normalizer that applies the named Normalizers in
+// order.
+func NewNormalizer(names ...string) (Normalizer, error) {
+	ns := make([]Normalizer, len(names))
+	for i, name := range names {
+		n, ok := Normalizers[name]
+		if !ok {
+			return nil, fmt.Errorf("strsim: unknown normalizer %q", name)
+		}
+		ns[i] = n
+	}
+	return Chain(ns...), nil
+}
+
+// Wrap takes a normalizer and a comparer and returns a comparer that
+// normalizes both strings before comparing them.
+func Wrap(n Normalizer, f Comparer) Comparer {
+	return func(a, b string) float64 {
+		return f(n(a), n(b))
+	}
+}
+
+// FoldCaseFor returns a Normalizer that applies Unicode full case folding
+// using the conventions of language t. Only Turkish and Azeri differ from
+// the default, folding I to dotless ı and İ to i.
+func FoldCaseFor(t language.Tag) Normalizer {
+	base, _ := t.Base()
+	turkic := base == turkish || base == azeri
+	return func(s string) string {
+		if turkic {
+			s = cases.Lower(t).String(s)
+		}
+		return strings.Map(foldCherokee, cases.Fold().String(s))
+	}
+}
+
+// foldCherokee folds Cherokee to upper case, as CaseFolding.txt does.
+// cases.Fold maps it the wrong way.
+func foldCherokee(r rune) rune {
+	if unicode.Is(unicode.Cherokee, r) {
+		return unicode.ToUpper(r)
+	}
+	return r
+}
+
+// WrapFoldCase takes a comparer and returns a comparer that does a case
+// insensitive comparison using full case folding.
+func WrapFoldCase(f Comparer) Comparer {
+	return Wrap(FoldCase, f)
+}
+
+// WrapFoldCaseFor is WrapFoldCase using the conventions of language t.
+func WrapFoldCaseFor(t language.Tag, f Comparer) Comparer {
+	return Wrap(FoldCaseFor(t), f)
+}
+
+var (
+	turkish, _ = language.Turkish.Base()
+	azeri, _   = language.Azerbaijani.Base()
+)
+
+// letters that have no decomposition but are commonly written without
+// their stroke.
+var strokes = strings.NewReplacer(
+	"ø", "o", "Ø", "O", "ł", "l", "Ł", "L", "đ", "d", "Đ", "D", "ħ", "h",
+	"Ħ", "H")
+
+// foldDiacritics removes the nonspacing marks on Latin, Greek and Cyrillic
+// letters. Marks in other scripts, such as the Japanese dakuten or the
+// Devanagari virama, change the letter rather than accent it, so are kept.
+func foldDiacritics(s string) string {
+	d := norm.NFD.String(s)
+	r := make([]rune, 0, len(d))
+	fold := false
+	for _, c := range d {
+		if !unicode.Is(unicode.Mn, c) {
+			fold = unicode.In(c, unicode.Latin, unicode.Greek,
+				unicode.Cyrillic)
+		} else if fold {
+			continue
+		}
+		r = append(r, c)
+	}
+	return strokes.Replace(norm.NFC.String(string(r)))
+}
+
+var punctuation = strings.NewReplacer(
+	"‘", "'", "’", "'", "‚", "'", "‛", "'", "′", "'", "`", "'", "´", "'",
+	"“", `"`, "”", `"`, "„", `"`, "‟", `"`, "″", `"`, "«", `"`, "»", `"`,
+	"‐", "-", "‑", "-", "‒", "-", "–", "-", "—", "-", "―", "-", "−", "-",
+	"…", "...")
+
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func isZeroWidth(r rune) bool {
+	switch {
+	case r >= '\u200b' && r <= '\u200f',
+		r >= '\u202a' && r <= '\u202e',
+		r >= '\u2060' && r <= '\u2064',
+		r >= '\u2066' && r <= '\u2069',
+		r == '\ufeff':
+		return true
+	}
+	return false
+}
+
+func stripZeroWidth(s string) string {
+	return strings.Map(func(r rune) rune {
+		if isZeroWidth(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
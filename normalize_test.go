@@ -0,0 +1,108 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim_test
+
+import (
+	"testing"
+
+	"github.com/charles-haynes/strsim"
+	"golang.org/x/text/language"
+)
+
+func TestNormalizers(t *testing.T) {
+	for _, c := range []struct {
+		n    strsim.Normalizer
+		s, e string
+	}{
+		{strsim.Lower, "The WIZRD", "the wizrd"},
+		{strsim.NFKC, "～marasy～", "~marasy~"},
+		{strsim.FoldDiacritics, "Formations Magnétiques", "Formations Magnetiques"},
+		{strsim.FoldDiacritics, "Stéphane Bjørn", "Stephane Bjorn"},
+		{strsim.FoldPunctuation, "D’incertitude “expiation” 1972–1975", `D'incertitude "expiation" 1972-1975`},
+		{strsim.CollapseSpace, "  The  Vursiflenze \t Mismantler ", "The Vursiflenze Mismantler"},
+		{strsim.StripZeroWidth, "Vol\u200b.\u200b2 (ريم بنا\u200e)", "Vol.2 (ريم بنا)"},
+		{strsim.Standard, "Formations Magnétiques et Phénomènes D’incertitude", "formations magnetiques et phenomenes d'incertitude"},
+	} {
+		if r := c.n(c.s); r != c.e {
+			t.Errorf("normalize(%q) = %q, expected %q", c.s, r, c.e)
+		}
+	}
+}
+
+func TestFoldCase(t *testing.T) {
+	for _, c := range []struct {
+		n    strsim.Normalizer
+		a, b string
+	}{
+		{strsim.FoldCase, "Straße", "STRASSE"},
+		{strsim.FoldCase, "ΟΔΥΣΣΕΥΣ", "οδυσσευς"},
+		{strsim.FoldCase, "ᏣᎳᎩ", "ꮳꮃꭹ"},
+		{strsim.FoldCaseFor(language.Turkish), "DİYARBAKIR", "diyarbakır"},
+		{strsim.FoldCaseFor(language.Azerbaijani), "İLHAM", "ilham"},
+	} {
+		if a, b := c.n(c.a), c.n(c.b); a != b {
+			t.Errorf("fold(%s) = %q, fold(%s) = %q, expected equal",
+				c.a, a, c.b, b)
+		}
+	}
+	if a, b := strsim.FoldCase("DIYARBAKIR"), strsim.FoldCaseFor(
+		language.Turkish)("DIYARBAKIR"); a == b {
+		t.Errorf("Turkish fold(DIYARBAKIR) = %q, expected dotless i", b)
+	}
+	f := strsim.WrapFoldCase(strsim.Levenshein)
+	if r := f("Straße", "STRASSE"); r != 1.0 {
+		t.Errorf("(Straße,STRASSE) = %5.3f, expected 1.0", r)
+	}
+}
+
+func TestNewNormalizer(t *testing.T) {
+	n, err := strsim.NewNormalizer("strip-zero-width", "lower")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := n("ERR REC Library Vol\u200b.\u200b2"); r != "err rec library vol.2" {
+		t.Errorf("normalize = %q", r)
+	}
+	if _, err := strsim.NewNormalizer("upper"); err == nil {
+		t.Error("NewNormalizer(upper) succeeded, expected error")
+	}
+}
+
+func TestWrap(t *testing.T) {
+	f := strsim.Wrap(strsim.Standard, strsim.StringCompare)
+	for _, n := range [][]string{
+		{"ERR REC Library Vol.2 Science & Technology", "ERR REC Library Vol\u200b.\u200b2 Science & Technology"},
+		{`Formations Magnétiques et Phénomènes D’incertitude`, `Formations Magnetiques Et Phenomenes D'incertitude`},
+		{`The Vursiflenze Mismantler`, `The  Vursiflenze Mismantler`},
+	} {
+		if r := f(n[0], n[1]); r != 1.0 {
+			t.Errorf("(%s,%s) = %5.3f, expected 1.0", n[0], n[1], r)
+		}
+	}
+	for _, n := range [][]string{
+		{"ガガ", "カカ"},
+		{"हिन्दी", "हिनदी"},
+	} {
+		if r := f(n[0], n[1]); r != 0.0 {
+			t.Errorf("(%s,%s) = %5.3f, expected 0.0", n[0], n[1], r)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Numbering is a volume, part, episode or other series number in a title.
+type Numbering struct {
+	// Label is the lower cased label of the number, e.g. "vol" or "part",
+	// or "" for a bare number.
+	Label string
+	// Number is the number in arabic numerals, e.g. "2", "3.5" or "1-6".
+	Number string
+}
+
+var (
+	// labelled only takes roman numerals of I, V, X and L, so that words
+	// like "Mix" or "Dim" after a label aren't numbers
+	labelled = regexp.MustCompile(`(?i)\b(vol(?:ume)?s?|pts?|part|chapter|ch|episode|step|phaze|phase|nos?|book|disc|cd|season|series)\b\.?\s*(\d+(?:\.\d+)?(?:\s*[-–]\s*\d+)?|[ivxl]+)\b`)
+	// romanEnd only takes unlabelled roman numerals of two or more
+	// letters, so that "Generation X" and "Malcolm X" aren't numbered
+	romanEnd = regexp.MustCompile(`\s([IVX]{2,})$`)
+	bare     = regexp.MustCompile(`(?:^|[^\w.'’\-–])(\d+(?:\.\d+)?)(?:$|[^\w\-–])`)
+	spaced   = regexp.MustCompile(`\s+([,.:;])`)
+	repeated = regexp.MustCompile(`([,.:;])[,.:;]+`)
+)
+
+var romanValues = map[byte]int{
+	'i': 1, 'v': 5, 'x': 10, 'l': 50, 'c': 100, 'd': 500, 'm': 1000,
+}
+
+// roman returns the value of the roman numeral s, or 0 if s is not a
+// canonical roman numeral.
+func roman(s string) int {
+	s = strings.ToLower(s)
+	n := 0
+	for i := 0; i < len(s); i++ {
+		v := romanValues[s[i]]
+		if v == 0 {
+			return 0
+		}
+		if i+1 < len(s) && romanValues[s[i+1]] > v {
+			n -= v
+		} else {
+			n += v
+		}
+	}
+	if n <= 0 || n >= 4000 || toRoman(n) != s {
+		return 0
+	}
+	return n
+}
+
+func toRoman(n int) string {
+	var b strings.Builder
+	for _, r := range []struct {
+		v int
+		s string
+	}{
+		{1000, "m"}, {900, "cm"}, {500, "d"}, {400, "cd"}, {100, "c"},
+		{90, "xc"}, {50, "l"}, {40, "xl"}, {10, "x"}, {9, "ix"}, {5, "v"},
+		{4, "iv"}, {1, "i"},
+	} {
+		for ; n >= r.v; n -= r.v {
+			b.WriteString(r.s)
+		}
+	}
+	return b.String()
+}
+
+// canonical returns the number s in arabic numerals without leading zeros,
+// or "" if s isn't a number.
+func canonical(s string) string {
+	if n := roman(s); n > 0 {
+		return strconv.Itoa(n)
+	}
+	parts := strings.FieldsFunc(s, func(r rune) bool {
+		return r == '-' || r == '–' || r == ' '
+	})
+	for i, p := range parts {
+		if strings.HasPrefix(p, ".") || p == "" || p[0] < '0' || p[0] > '9' {
+			return ""
+		}
+		p = strings.TrimLeft(p, "0")
+		if p == "" || p[0] == '.' {
+			p = "0" + p
+		}
+		parts[i] = p
+	}
+	return strings.Join(parts, "-")
+}
+
+func isYear(s string) bool {
+	n, err := strconv.Atoi(s)
+	return err == nil && len(s) == 4 && n >= 1900 && n < 2100
+}
+
+// ParseNumbering removes the series numbers from a title such as
+// "Brazilliance Vol. 1", "A Different Kind of Human (Step II)" or
+// "FRKWYS 15: Serenitatem" and returns what is left of the title and the
+// numbers in the order they appear. Four digit years are not numbers.
+func ParseNumbering(s string) (string, []Numbering) {
+	type found struct {
+		start, end int
+		n          Numbering
+	}
+	var fs []found
+	cut := []byte(s)
+	blank := func(start, end int) {
+		for i := start; i < end; i++ {
+			cut[i] = ' '
+		}
+	}
+	for _, m := range labelled.FindAllStringSubmatchIndex(s, -1) {
+		n := canonical(s[m[4]:m[5]])
+		if n == "" {
+			continue
+		}
+		label := strings.ToLower(s[m[2]:m[3]])
+		if label != "series" {
+			label = strings.TrimSuffix(label, "s")
+		}
+		fs = append(fs, found{m[0], m[1], Numbering{label, n}})
+		blank(m[0], m[1])
+	}
+	if m := romanEnd.FindStringSubmatchIndex(string(cut)); m != nil {
+		if n := roman(s[m[2]:m[3]]); n > 0 {
+			fs = append(fs, found{m[2], m[3],
+				Numbering{"", strconv.Itoa(n)}})
+			blank(m[2], m[3])
+		}
+	}
+	for _, m := range bare.FindAllStringSubmatchIndex(string(cut), -1) {
+		if isYear(s[m[2]:m[3]]) {
+			continue
+		}
+		fs = append(fs, found{m[2], m[3],
+			Numbering{"", canonical(s[m[2]:m[3]])}})
+		blank(m[2], m[3])
+	}
+	sort.Slice(fs, func(i, j int) bool { return fs[i].start < fs[j].start })
+	var ns []Numbering
+	for _, f := range fs {
+		ns = append(ns, f.n)
+	}
+	r := CollapseSpace(string(cut))
+	r = repeated.ReplaceAllString(spaced.ReplaceAllString(r, "$1"), "$1")
+	r = strings.Trim(r, " ,.:;-–(")
+	r = strings.Replace(strings.Replace(r, "()", "", -1), "( )", "", -1)
+	return CollapseSpace(r), ns
+}
+
+// NumberingPenalties are the proportions by which a score is reduced when
+// both titles have series numbers that differ, or when only one of them
+// has series numbers.
+type NumberingPenalties struct {
+	Mismatch, Missing float64
+}
+
+// DefaultNumberingPenalties halve the score of titles with different
+// series numbers.
+var DefaultNumberingPenalties = NumberingPenalties{Mismatch: 0.5}
+
+// NewNumberedComparer returns a comparer that compares titles without their
+// series numbers using f and then penalizes the score using p if the
+// numbers differ. Labels are ignored, so "FRKWYS Vol. 15" matches
+// "FRKWYS 15".
+func NewNumberedComparer(f Comparer, p NumberingPenalties) Comparer {
+	return func(a, b string) float64 {
+		ba, na := ParseNumbering(a)
+		bb, nb := ParseNumbering(b)
+		s := compareParts(f, ba, bb)
+		switch {
+		case len(na) == 0 && len(nb) == 0:
+		case len(na) == 0 || len(nb) == 0:
+			s *= 1 - p.Missing
+		case !sameNumbers(na, nb):
+			s *= 1 - p.Mismatch
+		}
+		return s
+	}
+}
+
+// WrapNumbering takes a comparer and returns a NewNumberedComparer using
+// DefaultNumberingPenalties.
+func WrapNumbering(f Comparer) Comparer {
+	return NewNumberedComparer(f, DefaultNumberingPenalties)
+}
+
+func sameNumbers(a, b []Numbering) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	count := map[string]int{}
+	for _, n := range a {
+		count[n.Number]++
+	}
+	for _, n := range b {
+		if count[n.Number] == 0 {
+			return false
+		}
+		count[n.Number]--
+	}
+	return true
+}
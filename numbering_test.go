@@ -0,0 +1,107 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charles-haynes/strsim"
+)
+
+func TestParseNumbering(t *testing.T) {
+	for _, c := range []struct {
+		s, b string
+		n    []strsim.Numbering
+	}{
+		{"Brazilliance Vol. 1", "Brazilliance", []strsim.Numbering{{"vol", "1"}}},
+		{"Brazilliance, Volume 2", "Brazilliance", []strsim.Numbering{{"volume", "2"}}},
+		{"Episode 1", "", []strsim.Numbering{{"episode", "1"}}},
+		{"Fantast Remixes, Pt. 2", "Fantast Remixes", []strsim.Numbering{{"pt", "2"}}},
+		{"FRKWYS Vol. 15: Serenitatem", "FRKWYS: Serenitatem", []strsim.Numbering{{"vol", "15"}}},
+		{"FRKWYS 15: Serenitatem", "FRKWYS: Serenitatem", []strsim.Numbering{{"", "15"}}},
+		{"Nova Tunes 3.5", "Nova Tunes", []strsim.Numbering{{"", "3.5"}}},
+		{"A Different Kind of Human (Step II)", "A Different Kind of Human", []strsim.Numbering{{"step", "2"}}},
+		{"The Budos Band III", "The Budos Band", []strsim.Numbering{{"", "3"}}},
+		{"The Budos Band V", "The Budos Band V", nil},
+		{"Generation X", "Generation X", nil},
+		{"Malcolm X", "Malcolm X", nil},
+		{"CD Mix", "CD Mix", nil},
+		{"Disc Vi", "", []strsim.Numbering{{"disc", "6"}}},
+		{"John Wick: Chapter 2", "John Wick", []strsim.Numbering{{"chapter", "2"}}},
+		{"Warehouse 10, Volume 8", "Warehouse", []strsim.Numbering{{"", "10"}, {"volume", "8"}}},
+		{"Dur Dur of Somalia Volume 1 / Volume 2", "Dur Dur of Somalia /", []strsim.Numbering{{"volume", "1"}, {"volume", "2"}}},
+		{"String Symphonies Nos. 1-6", "String Symphonies", []strsim.Numbering{{"no", "1-6"}}},
+		{"ERR REC Library Vol.2 Science", "ERR REC Library Science", []strsim.Numbering{{"vol", "2"}}},
+		{"Live At Carnegie Hall 1977", "Live At Carnegie Hall 1977", nil},
+		{"Sounds from the Two Congos 1955-62", "Sounds from the Two Congos 1955-62", nil},
+		{"Jack Le Freak (Extended Remix '87)", "Jack Le Freak (Extended Remix '87)", nil},
+		{"N9NA Collection 2", "N9NA Collection", []strsim.Numbering{{"", "2"}}},
+		{"Voice Of Resistance", "Voice Of Resistance", nil},
+	} {
+		b, n := strsim.ParseNumbering(c.s)
+		if b != c.b || !reflect.DeepEqual(n, c.n) {
+			t.Errorf("ParseNumbering(%s) = %q, %v, expected %q, %v",
+				c.s, b, n, c.b, c.n)
+		}
+	}
+}
+
+func TestNumberedComparer(t *testing.T) {
+	for s, f := range Sims {
+		nf := strsim.WrapNumbering(f)
+		for _, n := range [][]string{
+			{"Generation X", "Generation"},
+			{"Malcolm X", "Malcolm"},
+			{"CD Mix", "CD"},
+		} {
+			if r, e := nf(n[0], n[1]), f(n[0], n[1]); r != e {
+				t.Errorf("%s(%s,%s) = %5.3f, expected %5.3f",
+					s, n[0], n[1], r, e)
+			}
+		}
+		for _, n := range [][]string{
+			{"Brazilliance Vol. 1", "Brazilliance Vol. 2"},
+			{"Episode 1", "Episode 2"},
+			{"Eurobeat Festival Vol. 1", "Eurobeat Festival Vol. 8"},
+			{"Nova Tunes 3.5", "Nova Tunes 3.9"},
+			{"Fantast Remixes, Pt. 2", "Fantast Remixes, Pt. 3"},
+		} {
+			if r := nf(n[0], n[1]); r != 0.5 {
+				t.Errorf("%s(%s,%s) = %5.3f, expected 0.5",
+					s, n[0], n[1], r)
+			}
+		}
+		for _, n := range [][]string{
+			{"FRKWYS Vol. 15: Serenitatem", "FRKWYS 15: Serenitatem"},
+			{"Heinz Music Best Of Vol. 1", "Heinz Music Best Of, Vol. 1"},
+			{"Warehouse 10, Volume 8", "Warehouse 10 Volume 8"},
+			{"Brazilliance, Volume 2", "Brazilliance Vol. 2"},
+			{"Episode 1", "Episode 1"},
+			{"Malcolm X", "Malcolm X"},
+		} {
+			if r := nf(n[0], n[1]); r != 1.0 {
+				t.Errorf("%s(%s,%s) = %5.3f, expected 1.0",
+					s, n[0], n[1], r)
+			}
+		}
+	}
+}
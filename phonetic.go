@@ -0,0 +1,367 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim
+
+import (
+	"strings"
+)
+
+// Encoder returns the phonetic codes of a word, most likely first. A word
+// with no letters it can encode has no codes.
+type Encoder func(word string) []string
+
+// ligatures are the letters that fold to more than one ASCII letter.
+var ligatures = strings.NewReplacer("ß", "SS", "æ", "AE", "Æ", "AE",
+	"œ", "OE", "Œ", "OE")
+
+// phoneticLetters returns the letters of word without diacritics and upper
+// cased, dropping anything that isn't A to Z, or a space if spaces.
+func phoneticLetters(word string, spaces bool) []byte {
+	s := strings.ToUpper(ligatures.Replace(FoldDiacritics(word)))
+	w := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c >= 'A' && c <= 'Z' || spaces && c == ' ' {
+			w = append(w, c)
+		}
+	}
+	return w
+}
+
+// at returns w[i], or 0 if i is outside w.
+func at(w []byte, i int) byte {
+	if i < 0 || i >= len(w) {
+		return 0
+	}
+	return w[i]
+}
+
+// isVowel reports whether c is A, E, I, O or U.
+func isVowel(c byte) bool {
+	return c != 0 && strings.IndexByte("AEIOU", c) >= 0
+}
+
+// soundexCodes are the Soundex digits of A to Z. Vowels are 0 and separate
+// letters with the same digit, H and W are - and don't.
+const soundexCodes = "0123012-02245501262301-202"
+
+// Soundex returns the American Soundex code of word, its first letter and
+// three digits for the consonants that follow.
+func Soundex(word string) []string {
+	w := phoneticLetters(word, false)
+	if len(w) == 0 {
+		return nil
+	}
+	code := []byte{w[0]}
+	last := soundexCodes[w[0]-'A']
+	for _, c := range w[1:] {
+		if len(code) == 4 {
+			break
+		}
+		d := soundexCodes[c-'A']
+		if d == '-' {
+			continue
+		}
+		if d != '0' && d != last {
+			code = append(code, d)
+		}
+		last = d
+	}
+	for len(code) < 4 {
+		code = append(code, '0')
+	}
+	return []string{string(code)}
+}
+
+// NYSIIS returns the New York State Identification and Intelligence System
+// code of word, without truncating it to six letters. Letters are
+// transcoded in place, so the rules that copy the letter before H or W copy
+// it as transcoded.
+func NYSIIS(word string) []string {
+	w := string(phoneticLetters(word, false))
+	if w == "" {
+		return nil
+	}
+	switch {
+	case strings.HasPrefix(w, "MAC"):
+		w = "MCC" + w[3:]
+	case strings.HasPrefix(w, "KN"):
+		w = w[1:]
+	case strings.HasPrefix(w, "K"):
+		w = "C" + w[1:]
+	case strings.HasPrefix(w, "PH"), strings.HasPrefix(w, "PF"):
+		w = "FF" + w[2:]
+	case strings.HasPrefix(w, "SCH"):
+		w = "SSS" + w[3:]
+	}
+	for _, s := range []string{"EE", "IE"} {
+		if strings.HasSuffix(w, s) {
+			w = w[:len(w)-2] + "Y"
+		}
+	}
+	for _, s := range []string{"DT", "RT", "RD", "NT", "ND"} {
+		if strings.HasSuffix(w, s) {
+			w = w[:len(w)-2] + "D"
+		}
+	}
+	b := []byte(w)
+	key := []byte{b[0]}
+	// prev is the previous letter as transcoded, which the H and W rules
+	// copy
+	prev := b[0]
+	for i := 1; i < len(b); i++ {
+		c, next := b[i], at(b, i+1)
+		t := string(c)
+		switch {
+		case c == 'E' && next == 'V':
+			t = "AF"
+			i++
+		case isVowel(c):
+			t = "A"
+		case c == 'Q':
+			t = "G"
+		case c == 'Z':
+			t = "S"
+		case c == 'M':
+			t = "N"
+		case c == 'K' && next == 'N':
+			t = "N"
+		case c == 'K':
+			t = "C"
+		case c == 'S' && next == 'C' && at(b, i+2) == 'H':
+			t = "SS"
+			i += 2
+		case c == 'P' && next == 'H':
+			t = "F"
+			i++
+		case c == 'H' && (!isVowel(prev) || !isVowel(next)):
+			t = string(prev)
+		case c == 'W' && isVowel(prev):
+			t = string(prev)
+		}
+		prev = t[len(t)-1]
+		if prev != key[len(key)-1] {
+			key = append(key, t...)
+		}
+	}
+	k := string(key)
+	if len(k) > 1 && strings.HasSuffix(k, "S") {
+		k = k[:len(k)-1]
+	}
+	if strings.HasSuffix(k, "AY") {
+		k = k[:len(k)-2] + "Y"
+	}
+	if len(k) > 1 && strings.HasSuffix(k, "A") {
+		k = k[:len(k)-1]
+	}
+	return []string{k}
+}
+
+// Metaphone returns the original Metaphone code of word, with 0 for "th"
+// and X for "sh".
+func Metaphone(word string) []string {
+	l := phoneticLetters(word, true)
+	var w []byte
+	for i, c := range l {
+		if i == 0 || c != l[i-1] || c == 'C' {
+			w = append(w, c)
+		}
+	}
+	if len(w) == 0 {
+		return nil
+	}
+	switch string(w[:min(2, len(w))]) {
+	case "KN", "GN", "PN", "AE", "WR":
+		w = w[1:]
+	case "WH":
+		w = append(w[:1], w[2:]...)
+	}
+	if w[0] == 'X' {
+		w[0] = 'S'
+	}
+	// end reports whether i is past the end of a word
+	end := func(i int) bool {
+		return i >= len(w) || w[i] == ' '
+	}
+	var code []byte
+	for i := 0; i < len(w); i++ {
+		c, prev, next, next2 := w[i], at(w, i-1), at(w, i+1), at(w, i+2)
+		switch c {
+		case 'A', 'E', 'I', 'O', 'U':
+			if i == 0 || prev == ' ' {
+				code = append(code, c)
+			}
+		case 'B':
+			if prev != 'M' || !end(i+1) {
+				code = append(code, 'B')
+			}
+		case 'C':
+			switch {
+			case next == 'I' && next2 == 'A':
+				code = append(code, 'X')
+			case next == 'H':
+				if prev == 'S' {
+					code = append(code, 'K')
+				} else {
+					code = append(code, 'X')
+				}
+				i++
+			case next == 'I' || next == 'E' || next == 'Y':
+				if prev != 'S' {
+					code = append(code, 'S')
+				}
+			default:
+				code = append(code, 'K')
+			}
+		case 'D':
+			if next == 'G' && (next2 == 'E' || next2 == 'I' || next2 == 'Y') {
+				code = append(code, 'J')
+				i++
+			} else {
+				code = append(code, 'T')
+			}
+		case 'G':
+			switch {
+			case next == 'H' && !end(i+2) && !isVowel(next2):
+				i++
+			case next == 'N' && (end(i+2) || next2 == 'E' &&
+				at(w, i+3) == 'D' && end(i+4)):
+			case next == 'I' || next == 'E' || next == 'Y':
+				code = append(code, 'J')
+			default:
+				code = append(code, 'K')
+			}
+		case 'H':
+			if !(isVowel(prev) && !isVowel(next)) &&
+				strings.IndexByte("CGPST", prev) < 0 {
+				code = append(code, 'H')
+			}
+		case 'K':
+			if prev != 'C' {
+				code = append(code, 'K')
+			}
+		case 'P':
+			if next == 'H' {
+				code = append(code, 'F')
+				i++
+			} else {
+				code = append(code, 'P')
+			}
+		case 'Q':
+			code = append(code, 'K')
+		case 'S':
+			switch {
+			case next == 'H':
+				code = append(code, 'X')
+				i++
+			case next == 'I' && (next2 == 'O' || next2 == 'A'):
+				code = append(code, 'X')
+			default:
+				code = append(code, 'S')
+			}
+		case 'T':
+			switch {
+			case next == 'I' && (next2 == 'O' || next2 == 'A'):
+				code = append(code, 'X')
+			case next == 'H':
+				code = append(code, '0')
+				i++
+			case next == 'C' && next2 == 'H':
+			default:
+				code = append(code, 'T')
+			}
+		case 'V':
+			code = append(code, 'F')
+		case 'W', 'Y':
+			if isVowel(next) {
+				code = append(code, c)
+			}
+		case 'X':
+			code = append(code, 'K', 'S')
+		case 'Z':
+			code = append(code, 'S')
+		case ' ':
+			if len(code) > 0 && code[len(code)-1] != ' ' {
+				code = append(code, ' ')
+			}
+		default:
+			code = append(code, c)
+		}
+	}
+	if s := strings.TrimSpace(string(code)); s != "" {
+		return []string{s}
+	}
+	return nil
+}
+
+// Phonetic returns a comparer that encodes each token of a and b with enc,
+// and scores them as ComparePhoneticKeys does. To compare one string
+// against many, make their keys once with NewPhoneticKey instead.
+//
+// Metaphone or Soundex suit spellings that drop vowels, such as "Hndrxx"
+// for "Hendrix". DoubleMetaphone drops a leading H before a consonant and
+// keeps only four letters, so they encode as NTRK and HNTR.
+func Phonetic(enc Encoder, inner Comparer) Comparer {
+	return func(a, b string) float64 {
+		return ComparePhoneticKeys(NewPhoneticKey(enc, a),
+			NewPhoneticKey(enc, b), inner)
+	}
+}
+
+// PhoneticKey is the codes of each token of a string.
+type PhoneticKey [][]string
+
+// NewPhoneticKey returns the codes from enc of each token of s. Tokens enc
+// has no code for, such as numbers, are kept as they are.
+func NewPhoneticKey(enc Encoder, s string) PhoneticKey {
+	ts := Tokens(s)
+	k := make(PhoneticKey, len(ts))
+	for i, t := range ts {
+		if k[i] = enc(t); len(k[i]) == 0 {
+			k[i] = []string{t}
+		}
+	}
+	return k
+}
+
+// ComparePhoneticKeys scores a and b as SymmetricMongeElkan does, taking
+// the score of two tokens as the best from inner of any of their codes, so
+// that each token can match on its primary or alternate code whatever the
+// others do.
+func ComparePhoneticKeys(a, b PhoneticKey, inner Comparer) float64 {
+	best := func(x, y []string) float64 {
+		max := 0.0
+		for _, cx := range x {
+			for _, cy := range y {
+				if m := inner(cx, cy); m > max {
+					max = m
+				}
+			}
+		}
+		return max
+	}
+	ab := mongeElkanBy(len(a), len(b), func(i, j int) float64 {
+		return best(a[i], b[j])
+	})
+	ba := mongeElkanBy(len(b), len(a), func(i, j int) float64 {
+		return best(b[i], a[j])
+	})
+	return (ab + ba) / 2
+}
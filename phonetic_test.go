@@ -0,0 +1,151 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charles-haynes/strsim"
+)
+
+func TestEncoders(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		enc  strsim.Encoder
+		w    string
+		e    []string
+	}{
+		{"soundex", strsim.Soundex, "Robert", []string{"R163"}},
+		{"soundex", strsim.Soundex, "Rupert", []string{"R163"}},
+		{"soundex", strsim.Soundex, "Ashcraft", []string{"A261"}},
+		{"soundex", strsim.Soundex, "Tymczak", []string{"T522"}},
+		{"soundex", strsim.Soundex, "Pfister", []string{"P236"}},
+		{"soundex", strsim.Soundex, "Honeyman", []string{"H555"}},
+		{"soundex", strsim.Soundex, "Lee", []string{"L000"}},
+		{"soundex", strsim.Soundex, "1972", nil},
+		{"nysiis", strsim.NYSIIS, "Worthy", []string{"WARTY"}},
+		{"nysiis", strsim.NYSIIS, "Catherine", []string{"CATARAN"}},
+		{"nysiis", strsim.NYSIIS, "Katherine", []string{"CATARAN"}},
+		{"nysiis", strsim.NYSIIS, "John", []string{"JAN"}},
+		{"nysiis", strsim.NYSIIS, "Jessica", []string{"JASAC"}},
+		{"nysiis", strsim.NYSIIS, "Montgomery", []string{"MANTGANARY"}},
+		{"nysiis", strsim.NYSIIS, "Martincevic", []string{"MARTANCAFAC"}},
+		{"nysiis", strsim.NYSIIS, "Teresa", []string{"TARAS"}},
+		{"nysiis", strsim.NYSIIS, "Tu", []string{"T"}},
+		{"nysiis", strsim.NYSIIS, "Joshua", []string{"JAS"}},
+		{"nysiis", strsim.NYSIIS, "Brown", []string{"BRAN"}},
+		{"nysiis", strsim.NYSIIS, "Lewis", []string{"L"}},
+		{"metaphone", strsim.Metaphone, "Knight", []string{"NT"}},
+		{"metaphone", strsim.Metaphone, "Smith", []string{"SM0"}},
+		{"metaphone", strsim.Metaphone, "Thumb", []string{"0M"}},
+		{"metaphone", strsim.Metaphone, "Science", []string{"SNS"}},
+		{"metaphone", strsim.Metaphone, "Philip", []string{"FLP"}},
+		{"metaphone", strsim.Metaphone, "Xavier", []string{"SFR"}},
+		{"metaphone", strsim.Metaphone, "Wright", []string{"RT"}},
+		{"metaphone", strsim.Metaphone, "Hendrix", []string{"HNTRKS"}},
+		{"metaphone", strsim.Metaphone, "Hndrxx", []string{"HNTRKS"}},
+		{"metaphone", strsim.Metaphone, "Dvořák", []string{"TFRK"}},
+		{"double metaphone", strsim.DoubleMetaphone, "Smith",
+			[]string{"SM0", "XMT"}},
+		{"double metaphone", strsim.DoubleMetaphone, "Schmidt",
+			[]string{"XMT", "SMT"}},
+		{"double metaphone", strsim.DoubleMetaphone, "Xavier",
+			[]string{"SF", "SFR"}},
+		{"double metaphone", strsim.DoubleMetaphone, "Gough", []string{"KF"}},
+		{"double metaphone", strsim.DoubleMetaphone, "Jose", []string{"HS"}},
+		{"double metaphone", strsim.DoubleMetaphone, "Arnow",
+			[]string{"ARN", "ARNF"}},
+		{"double metaphone", strsim.DoubleMetaphone, "Kulthum",
+			[]string{"KL0M", "KLTM"}},
+		{"double metaphone", strsim.DoubleMetaphone, "Kalthoum",
+			[]string{"KL0M", "KLTM"}},
+		{"double metaphone", strsim.DoubleMetaphone, "", nil},
+	} {
+		if r := c.enc(c.w); !reflect.DeepEqual(r, c.e) {
+			t.Errorf("%s(%s) = %q, expected %q", c.name, c.w, r, c.e)
+		}
+	}
+}
+
+func TestPhonetic(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		f    strsim.Comparer
+		a, b string
+		e    float64
+	}{
+		{"soundex", strsim.Phonetic(strsim.Soundex, strsim.StringCompare),
+			"Robert", "Rupert", 1.0},
+		{"soundex", strsim.Phonetic(strsim.Soundex, strsim.StringCompare),
+			"Vol 1", "Vol. 1", 1.0},
+		{"soundex", strsim.Phonetic(strsim.Soundex, strsim.StringCompare),
+			"Vol 1", "Vol 2", 0.5},
+		{"metaphone", strsim.Phonetic(strsim.Metaphone, strsim.StringCompare),
+			"Hendrix", "Hndrxx", 1.0},
+		{"double metaphone",
+			strsim.Phonetic(strsim.DoubleMetaphone, strsim.StringCompare),
+			"Umm Kulthum", "Umm Kalthoum", 1.0},
+		{"double metaphone",
+			strsim.Phonetic(strsim.DoubleMetaphone, strsim.StringCompare),
+			"Patti Smith", "Patti Schmidt", 1.0},
+		{"double metaphone",
+			strsim.Phonetic(strsim.DoubleMetaphone, strsim.StringCompare),
+			"Patti Smith", "Patti Jones", 0.5},
+		{"double metaphone",
+			strsim.Phonetic(strsim.DoubleMetaphone, strsim.StringCompare),
+			"Smith Schmidt", "Schmidt Schmidt", 1.0},
+		{"double metaphone",
+			strsim.Phonetic(strsim.DoubleMetaphone, strsim.StringCompare),
+			"Jones", "", 0.0},
+		{"double metaphone",
+			strsim.Phonetic(strsim.DoubleMetaphone, strsim.JaroWinkler),
+			"Hendrix", "Hndrxx", 0.0},
+		{"metaphone", strsim.Phonetic(strsim.Metaphone, strsim.JaroWinkler),
+			"Hendrix", "Hndrxx", 1.0},
+		{"soundex", strsim.Phonetic(strsim.Soundex, strsim.JaroWinkler),
+			"Hendrix", "Hndrxx", 1.0},
+	} {
+		if r := c.f(c.a, c.b); r != c.e {
+			t.Errorf("%s(%s,%s) = %5.3f, expected %5.3f",
+				c.name, c.a, c.b, r, c.e)
+		}
+	}
+}
+
+func TestPhoneticKey(t *testing.T) {
+	f := strsim.Phonetic(strsim.DoubleMetaphone, strsim.JaroWinkler)
+	ks := make([]strsim.PhoneticKey, len(GroupsEqual))
+	for i, n := range GroupsEqual {
+		ks[i] = strsim.NewPhoneticKey(strsim.DoubleMetaphone, n[1])
+	}
+	for _, n := range GroupsEqual[:10] {
+		q := strsim.NewPhoneticKey(strsim.DoubleMetaphone, n[0])
+		for i, m := range GroupsEqual {
+			e := f(n[0], m[1])
+			r := strsim.ComparePhoneticKeys(q, ks[i], strsim.JaroWinkler)
+			if r != e {
+				t.Errorf("ComparePhoneticKeys(%s,%s) = %5.3f, "+
+					"expected %5.3f", n[0], m[1], r, e)
+			}
+		}
+	}
+}
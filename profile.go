@@ -0,0 +1,169 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim
+
+import (
+	"sort"
+	"sync"
+)
+
+// Profile is the q-grams of a string, hashed and sorted, so that one string
+// can be compared against many without its q-grams being found again.
+type Profile struct {
+	grams []uint64
+}
+
+// NewProfile returns the profile of the q-grams of the runes of s, found as
+// NewQGramProfile finds them.
+func NewProfile(s string, o QGramOptions) Profile {
+	r := []rune(s)
+	g := make([]uint64, 0, len(r)+2*o.q())
+	forGrams(r, o, func(b []byte) {
+		g = append(g, fnv64a(b))
+	})
+	sort.Slice(g, func(i, j int) bool { return g[i] < g[j] })
+	if o.Set {
+		n := 0
+		for i, h := range g {
+			if i == 0 || h != g[n-1] {
+				g[n] = h
+				n++
+			}
+		}
+		g = g[:n]
+	}
+	return Profile{g}
+}
+
+// fnv64a returns the 64 bit FNV-1a hash of b.
+func fnv64a(b []byte) uint64 {
+	h := uint64(14695981039346656037)
+	for _, c := range b {
+		h ^= uint64(c)
+		h *= 1099511628211
+	}
+	return h
+}
+
+// Len returns the number of q-grams in p.
+func (p Profile) Len() int {
+	return len(p.grams)
+}
+
+// Common returns the number of q-grams p and o have in common.
+func (p Profile) Common(o Profile) int {
+	a, b := p.grams, o.grams
+	c := 0
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			c++
+			i++
+			j++
+		}
+	}
+	return c
+}
+
+// CompareProfiles returns the q-grams a and b have in common over all
+// their q-grams, as QGramJaccard does, without allocating. For profiles
+// made with zero options it is CommonTrigrams.
+func CompareProfiles(a, b Profile) float64 {
+	if len(a.grams) == 0 || len(b.grams) == 0 {
+		if len(a.grams) == len(b.grams) {
+			return 1.0
+		}
+		return 0.0
+	}
+	c := a.Common(b)
+	return float64(c) / float64(len(a.grams)+len(b.grams)-c)
+}
+
+// ProfileIndex is the profiles of a catalog of strings, made once up front
+// so that queries can be compared against the catalog without profiling it
+// again. It also keeps the profile of the last string it was given that is
+// not in the catalog, so that comparing one query against the catalog
+// only profiles the query once. It is safe for concurrent use.
+type ProfileIndex struct {
+	o        QGramOptions
+	profiles map[string]Profile
+
+	mu          sync.Mutex
+	last        string
+	lastProfile *Profile
+}
+
+// NewProfileIndex returns the index of the profiles of the strings in
+// catalog.
+func NewProfileIndex(catalog []string, o QGramOptions) *ProfileIndex {
+	x := &ProfileIndex{
+		o:        o,
+		profiles: make(map[string]Profile, len(catalog)),
+	}
+	for _, s := range catalog {
+		if _, ok := x.profiles[s]; !ok {
+			x.profiles[s] = NewProfile(s, o)
+		}
+	}
+	return x
+}
+
+// Profile returns the profile of s, from the index if s is in the catalog
+// or was the last string profiled that isn't.
+func (x *ProfileIndex) Profile(s string) Profile {
+	if p, ok := x.profiles[s]; ok {
+		return p
+	}
+	x.mu.Lock()
+	if x.lastProfile != nil && x.last == s {
+		p := *x.lastProfile
+		x.mu.Unlock()
+		return p
+	}
+	x.mu.Unlock()
+	p := NewProfile(s, x.o)
+	x.mu.Lock()
+	x.last, x.lastProfile = s, &p
+	x.mu.Unlock()
+	return p
+}
+
+// Comparer returns a comparer like QGramJaccard that uses the index for
+// the profiles of a and b.
+func (x *ProfileIndex) Comparer() Comparer {
+	return func(a, b string) float64 {
+		return CompareProfiles(x.Profile(a), x.Profile(b))
+	}
+}
+
+// Query profiles q once and returns a function that compares it against a
+// string as the Comparer does, for matching one query against many
+// strings in the catalog.
+func (x *ProfileIndex) Query(q string) func(s string) float64 {
+	p := x.Profile(q)
+	return func(s string) float64 {
+		return CompareProfiles(p, x.Profile(s))
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/charles-haynes/strsim"
+)
+
+func TestProfile(t *testing.T) {
+	check := func(a, b string) {
+		e := strsim.CommonTrigrams(a, b)
+		pa := strsim.NewProfile(a, strsim.QGramOptions{})
+		pb := strsim.NewProfile(b, strsim.QGramOptions{})
+		if r := strsim.CompareProfiles(pa, pb); math.Abs(r-e) > 1e-9 {
+			t.Errorf("CompareProfiles(%s,%s) = %5.3f, "+
+				"expected %5.3f", a, b, r, e)
+		}
+	}
+	for _, n := range GroupsEqual {
+		check(n[0], n[1])
+	}
+	for _, c := range [][2]string{{"", ""}, {"", "a"}, {"ab", "ab"},
+		{"ab", "abc"}, {"ab", "ac"}} {
+		check(c[0], c[1])
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		check(randomString(r, r.Intn(20)), randomString(r, r.Intn(20)))
+	}
+}
+
+func TestProfileIndex(t *testing.T) {
+	cs := make([]string, 0, len(GroupsEqual))
+	for _, n := range GroupsEqual {
+		cs = append(cs, n[1])
+	}
+	for _, o := range []strsim.QGramOptions{
+		{Q: 2}, {Set: true}, {Q: 4, PadStart: true, PadEnd: true},
+	} {
+		f := strsim.QGramJaccard(o)
+		x := strsim.NewProfileIndex(cs, o)
+		g := x.Comparer()
+		check := func(a, b string, r float64) {
+			if e := f(a, b); math.Abs(r-e) > 1e-9 {
+				t.Errorf("ProfileIndex(%+v)(%s,%s) = %5.3f, "+
+					"expected %5.3f", o, a, b, r, e)
+			}
+		}
+		for _, n := range GroupsEqual {
+			check(n[0], n[1], g(n[0], n[1]))
+			check(n[1], n[0], g(n[1], n[0]))
+			check(n[0], n[0], g(n[0], n[0]))
+			q := x.Query(n[0])
+			for _, c := range cs[:5] {
+				check(n[0], c, q(c))
+			}
+		}
+	}
+	x := strsim.NewProfileIndex(cs, strsim.QGramOptions{})
+	q, g := x.Query(Values["long"][0]), x.Comparer()
+	if n := testing.AllocsPerRun(10, func() {
+		for _, c := range cs {
+			q(c)
+			g(Values["long"][0], c)
+		}
+	}); n != 0 {
+		t.Errorf("ProfileIndex allocates %v times", n)
+	}
+}
+
+func TestCompareProfilesAllocs(t *testing.T) {
+	a := strsim.NewProfile(Values["long"][0], strsim.QGramOptions{})
+	b := strsim.NewProfile(Values["long"][1], strsim.QGramOptions{})
+	if n := testing.AllocsPerRun(10, func() {
+		strsim.CompareProfiles(a, b)
+	}); n != 0 {
+		t.Errorf("CompareProfiles allocates %v times", n)
+	}
+}
+
+func BenchmarkProfile(b *testing.B) {
+	q := Values["long"][0]
+	cs := make([]string, 0, len(GroupsEqual))
+	for _, n := range GroupsEqual {
+		cs = append(cs, n[1])
+	}
+	b.Run("common trigrams", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, c := range cs {
+				strsim.CommonTrigrams(q, c)
+			}
+		}
+	})
+	b.Run("compare profiles", func(b *testing.B) {
+		ps := make([]strsim.Profile, len(cs))
+		for i, c := range cs {
+			ps[i] = strsim.NewProfile(c, strsim.QGramOptions{})
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			p := strsim.NewProfile(q, strsim.QGramOptions{})
+			for _, c := range ps {
+				strsim.CompareProfiles(p, c)
+			}
+		}
+	})
+}
@@ -0,0 +1,219 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim
+
+import (
+	"math"
+	"unicode/utf8"
+)
+
+// QGramOptions configure how a string is split into q-grams.
+type QGramOptions struct {
+	// Q is the number of runes in a gram. Zero means 3, as
+	// CommonTrigrams uses.
+	Q int
+	// PadStart and PadEnd pad the string with Q-1 pad runes, which match
+	// no rune of the string, so that its first and last runes start and
+	// end as many grams as the runes in the middle.
+	PadStart, PadEnd bool
+	// Set counts each distinct gram once, rather than as many times as
+	// it occurs.
+	Set bool
+}
+
+func (o QGramOptions) q() int {
+	if o.Q <= 0 {
+		return 3
+	}
+	return o.Q
+}
+
+// padByte is how a pad rune appears in a gram. It is never part of valid
+// UTF-8, and []rune replaces invalid input with utf8.RuneError, so it can't
+// come from the string.
+const padByte = 0xff
+
+// QGramProfile is the q-grams of a string and how many times each occurs.
+type QGramProfile struct {
+	Grams map[string]int
+	n     int
+}
+
+// NewQGramProfile returns the profile of the q-grams of the runes of s. A
+// non-empty string shorter than Q, even after any padding, is padded at the
+// end to a single gram, so short strings are compared by their runes
+// rather than only matching if they are equal.
+func NewQGramProfile(s string, o QGramOptions) QGramProfile {
+	return qgramProfile([]rune(s), o)
+}
+
+func qgramProfile(r []rune, o QGramOptions) QGramProfile {
+	p := QGramProfile{Grams: map[string]int{}}
+	forGrams(r, o, func(g []byte) {
+		if o.Set && p.Grams[string(g)] > 0 {
+			return
+		}
+		p.Grams[string(g)]++
+		p.n++
+	})
+	return p
+}
+
+// forGrams calls f with each q-gram of r, padded as o says, encoded as
+// UTF-8 with pad runes as padByte. f must not keep g.
+func forGrams(r []rune, o QGramOptions, f func(g []byte)) {
+	if len(r) == 0 {
+		return
+	}
+	q := o.q()
+	pad := q - 1
+	start, end := 0, 0
+	if o.PadStart {
+		start = pad
+	}
+	if o.PadEnd {
+		end = pad
+	}
+	if n := start + len(r) + end; n < q {
+		end += q - n
+	}
+	n := start + len(r) + end
+	g := make([]byte, 0, q*utf8.UTFMax)
+	for i := q; i <= n; i++ {
+		g = g[:0]
+		for j := i - q; j < i; j++ {
+			if j < start || j >= start+len(r) {
+				g = append(g, padByte)
+				continue
+			}
+			var buf [utf8.UTFMax]byte
+			g = append(g, buf[:utf8.EncodeRune(buf[:], r[j-start])]...)
+		}
+		f(g)
+	}
+}
+
+// Len returns the number of grams in p.
+func (p QGramProfile) Len() int {
+	return p.n
+}
+
+// Common returns the number of grams p and o have in common.
+func (p QGramProfile) Common(o QGramProfile) int {
+	if len(o.Grams) < len(p.Grams) {
+		p, o = o, p
+	}
+	c := 0
+	for g, n := range p.Grams {
+		if m := o.Grams[g]; m < n {
+			c += m
+		} else {
+			c += n
+		}
+	}
+	return c
+}
+
+// Dot returns the dot product of the gram counts of p and o.
+func (p QGramProfile) Dot(o QGramProfile) int {
+	if len(o.Grams) < len(p.Grams) {
+		p, o = o, p
+	}
+	d := 0
+	for g, n := range p.Grams {
+		d += n * o.Grams[g]
+	}
+	return d
+}
+
+// Norm returns the euclidean length of the gram counts of p.
+func (p QGramProfile) Norm() float64 {
+	s := 0
+	for _, n := range p.Grams {
+		s += n * n
+	}
+	return math.Sqrt(float64(s))
+}
+
+// qgramComparer returns a comparer that scores the profiles of a and b
+// with score. Two strings with no grams are equal, and one with no grams
+// matches nothing.
+func qgramComparer(o QGramOptions, score func(a, b QGramProfile) float64) Comparer {
+	return func(a, b string) float64 {
+		pa, pb := NewQGramProfile(a, o), NewQGramProfile(b, o)
+		if pa.n == 0 || pb.n == 0 {
+			if pa.n == pb.n {
+				return 1.0
+			}
+			return 0.0
+		}
+		return score(pa, pb)
+	}
+}
+
+// QGramJaccard returns a comparer of the q-grams a and b have in common
+// over all their q-grams. With zero options it is CommonTrigrams for
+// strings of at least 3 runes.
+func QGramJaccard(o QGramOptions) Comparer {
+	return QGramTversky(o, 1, 1)
+}
+
+// QGramDice returns a comparer of twice the q-grams a and b have in common
+// over their total q-grams, the Sørensen–Dice coefficient.
+func QGramDice(o QGramOptions) Comparer {
+	return QGramTversky(o, 0.5, 0.5)
+}
+
+// QGramOverlap returns a comparer of the q-grams a and b have in common
+// over the q-grams of the one with fewer, so that a string scores 1.0
+// against anything containing all its q-grams.
+func QGramOverlap(o QGramOptions) Comparer {
+	return qgramComparer(o, func(a, b QGramProfile) float64 {
+		m := a.n
+		if b.n < m {
+			m = b.n
+		}
+		return float64(a.Common(b)) / float64(m)
+	})
+}
+
+// QGramCosine returns a comparer of the cosine of the angle between the
+// q-gram counts of a and b.
+func QGramCosine(o QGramOptions) Comparer {
+	return qgramComparer(o, func(a, b QGramProfile) float64 {
+		return float64(a.Dot(b)) / (a.Norm() * b.Norm())
+	})
+}
+
+// QGramTversky returns a comparer of the Tversky index of the q-grams of a
+// and b, the common q-grams over themselves plus alpha times those only in
+// a and beta times those only in b. Alpha and beta of 1 is QGramJaccard,
+// and of 0.5 is QGramDice.
+func QGramTversky(o QGramOptions, alpha, beta float64) Comparer {
+	return qgramComparer(o, func(a, b QGramProfile) float64 {
+		c := float64(a.Common(b))
+		d := c + alpha*(float64(a.n)-c) + beta*(float64(b.n)-c)
+		if d == 0 {
+			return 0.0
+		}
+		return c / d
+	})
+}
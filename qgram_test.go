@@ -0,0 +1,83 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim_test
+
+import (
+	"math"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/charles-haynes/strsim"
+)
+
+func TestQGram(t *testing.T) {
+	for _, n := range GroupsEqual {
+		if utf8.RuneCountInString(n[0]) < 3 ||
+			utf8.RuneCountInString(n[1]) < 3 {
+			continue
+		}
+		e := strsim.CommonTrigrams(n[0], n[1])
+		r := strsim.QGramJaccard(strsim.QGramOptions{})(n[0], n[1])
+		if math.Abs(r-e) > 1e-9 {
+			t.Errorf("QGramJaccard(%s,%s) = %5.3f, expected %5.3f",
+				n[0], n[1], r, e)
+		}
+	}
+	pad := strsim.QGramOptions{PadStart: true, PadEnd: true}
+	bigrams := strsim.QGramOptions{Q: 2}
+	for _, c := range []struct {
+		name string
+		f    strsim.Comparer
+		a, b string
+		e    float64
+	}{
+		{"jaccard", strsim.QGramJaccard(strsim.QGramOptions{}), "ab", "ab", 1.0},
+		{"jaccard", strsim.QGramJaccard(strsim.QGramOptions{}), "ab", "ac", 0.0},
+		{"jaccard", strsim.QGramJaccard(pad), "ab", "abc", 2.0 / 7},
+		{"jaccard", strsim.QGramJaccard(pad), "", "", 1.0},
+		{"jaccard", strsim.QGramJaccard(pad), "", "a", 0.0},
+		{"jaccard", strsim.QGramJaccard(bigrams), "aaaa", "aaa", 2.0 / 3},
+		{"jaccard", strsim.QGramJaccard(strsim.QGramOptions{Q: 2, Set: true}),
+			"aaaa", "aaa", 1.0},
+		{"dice", strsim.QGramDice(bigrams), "night", "nacht", 0.25},
+		{"overlap", strsim.QGramOverlap(bigrams), "ht", "night", 1.0},
+		{"cosine", strsim.QGramCosine(strsim.QGramOptions{Q: 1}), "aab", "ab",
+			3 / math.Sqrt(10)},
+		{"tversky", strsim.QGramTversky(bigrams, 1, 0), "night", "nacht", 0.25},
+		{"tversky", strsim.QGramTversky(bigrams, 0, 1), "ht", "night", 0.25},
+	} {
+		if r := c.f(c.a, c.b); math.Abs(r-c.e) > 1e-9 {
+			t.Errorf("%s(%s,%s) = %5.3f, expected %5.3f",
+				c.name, c.a, c.b, r, c.e)
+		}
+	}
+}
+
+func TestQGramProfile(t *testing.T) {
+	p := strsim.NewQGramProfile("abab", strsim.QGramOptions{Q: 2})
+	if p.Len() != 3 || p.Grams["ab"] != 2 || p.Grams["ba"] != 1 {
+		t.Errorf("NewQGramProfile(abab) = %+v", p)
+	}
+	p = strsim.NewQGramProfile("a", strsim.QGramOptions{PadStart: true})
+	if p.Len() != 1 || p.Grams["\xff\xffa"] != 1 {
+		t.Errorf("NewQGramProfile(a) = %+v", p)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim
+
+import (
+	"io"
+)
+
+// QGramTFIDF is a model of how common each q-gram is in a corpus, used to
+// compare strings by the cosine of their tf-idf weighted q-grams, so that
+// q-grams common in the corpus, such as "the", "ion" or "vol" in release
+// titles, count for less than rare ones.
+type QGramTFIDF struct {
+	Options QGramOptions `json:"options"`
+	DocFreq DocFreq      `json:"docFreq"`
+}
+
+// NewQGramTFIDF returns a QGramTFIDF model of the q-grams o finds, fitted to
+// corpus.
+func NewQGramTFIDF(o QGramOptions, corpus []string) *QGramTFIDF {
+	m := &QGramTFIDF{Options: o}
+	for _, s := range corpus {
+		m.Add(s)
+	}
+	return m
+}
+
+// terms returns the q-grams of s as valid UTF-8, so that the model can be
+// saved as JSON. Pad runes are "\x00\x01" and NULs are "\x00\x00".
+func (m *QGramTFIDF) terms(s string) []string {
+	var ts []string
+	forGrams([]rune(s), m.Options, func(g []byte) {
+		t := make([]byte, 0, len(g))
+		for _, c := range g {
+			switch c {
+			case padByte:
+				t = append(t, 0, 1)
+			case 0:
+				t = append(t, 0, 0)
+			default:
+				t = append(t, c)
+			}
+		}
+		ts = append(ts, string(t))
+	})
+	return ts
+}
+
+// Add adds the q-grams of s to the model.
+func (m *QGramTFIDF) Add(s string) {
+	m.DocFreq.Add(m.terms(s))
+}
+
+// AddLines adds each line read from r to the model.
+func (m *QGramTFIDF) AddLines(r io.Reader) error {
+	return addLines(r, m.Add)
+}
+
+// Compare returns the cosine of the tf-idf weighted q-grams of a and b. The
+// model must not be changed while it is in use.
+func (m *QGramTFIDF) Compare(a, b string) float64 {
+	ta, wa := m.DocFreq.weights(m.terms(a))
+	tb, wb := m.DocFreq.weights(m.terms(b))
+	if len(ta) == 0 || len(tb) == 0 {
+		if len(ta) == len(tb) {
+			return 1.0
+		}
+		return 0.0
+	}
+	sim := 0.0
+	for i, j := 0, 0; i < len(ta) && j < len(tb); {
+		switch {
+		case ta[i] < tb[j]:
+			i++
+		case ta[i] > tb[j]:
+			j++
+		default:
+			sim += wa[i] * wb[j]
+			i++
+			j++
+		}
+	}
+	if sim > 1.0 {
+		return 1.0
+	}
+	return sim
+}
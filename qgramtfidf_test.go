@@ -0,0 +1,99 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim_test
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/charles-haynes/strsim"
+)
+
+func TestQGramTFIDF(t *testing.T) {
+	o := strsim.QGramOptions{PadStart: true, PadEnd: true}
+	m := strsim.NewQGramTFIDF(o, catalog)
+	for _, c := range []struct {
+		a, b string
+		e    float64
+	}{
+		{"Live at Leeds", "Live at Leeds", 1.0},
+		{"", "", 1.0},
+		{"", "Live", 0.0},
+		{"abc", "xyz", 0.0},
+	} {
+		if r := m.Compare(c.a, c.b); math.Abs(r-c.e) > 1e-9 {
+			t.Errorf("QGramTFIDF(%s,%s) = %5.3f, expected %5.3f",
+				c.a, c.b, r, c.e)
+		}
+	}
+	// with no corpus it is the cosine of the q-gram counts
+	empty := strsim.NewQGramTFIDF(o, nil)
+	cosine := strsim.QGramCosine(o)
+	for _, n := range GroupsEqual {
+		if r, e := empty.Compare(n[0], n[1]), cosine(n[0], n[1]); math.Abs(r-e) > 1e-9 {
+			t.Errorf("QGramTFIDF(%s,%s) = %5.3f, expected %5.3f",
+				n[0], n[1], r, e)
+		}
+	}
+	if r, e := m.Compare("Live at Leeds", "Live at Budokan"),
+		empty.Compare("Live at Leeds", "Live at Budokan"); r >= e {
+		t.Errorf("QGramTFIDF(Live at Leeds,Live at Budokan) = %5.3f, "+
+			"expected less than untrained %5.3f", r, e)
+	}
+}
+
+func TestQGramTFIDFFit(t *testing.T) {
+	o := strsim.QGramOptions{PadStart: true, PadEnd: true}
+	m := strsim.NewQGramTFIDF(o, catalog[:4])
+	for _, s := range catalog[4:] {
+		m.Add(s)
+	}
+	r := strsim.NewQGramTFIDF(o, nil)
+	if err := r.AddLines(strings.NewReader(strings.Join(catalog, "\n"))); err != nil {
+		t.Fatalf("AddLines: %v", err)
+	}
+	j, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var u strsim.QGramTFIDF
+	if err := json.Unmarshal(j, &u); err != nil {
+		t.Fatalf("Unmarshal(%s): %v", j, err)
+	}
+	e := strsim.NewQGramTFIDF(o, catalog)
+	for _, n := range GroupsEqual {
+		s := e.Compare(n[0], n[1])
+		if a := m.Compare(n[0], n[1]); a != s {
+			t.Errorf("Added QGramTFIDF(%s,%s) = %5.3f, expected %5.3f",
+				n[0], n[1], a, s)
+		}
+		if a := r.Compare(n[0], n[1]); a != s {
+			t.Errorf("AddLines QGramTFIDF(%s,%s) = %5.3f, expected %5.3f",
+				n[0], n[1], a, s)
+		}
+		if a := u.Compare(n[0], n[1]); a != s {
+			t.Errorf("Unmarshaled QGramTFIDF(%s,%s) = %5.3f, expected %5.3f",
+				n[0], n[1], a, s)
+		}
+	}
+}
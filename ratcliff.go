@@ -0,0 +1,143 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim
+
+// RatcliffObershelpOptions configure a comparer returned by
+// RatcliffObershelpWith. They follow the arguments of Python's
+// difflib.SequenceMatcher.
+type RatcliffObershelpOptions struct {
+	// IsJunk reports runes of b that matches may not start or end with.
+	IsJunk func(r rune) bool
+	// AutoJunk treats runes that make up more than 1% of b as junk when
+	// b is at least 200 runes long.
+	AutoJunk bool
+}
+
+// RatcliffObershelp returns the gestalt pattern matching similarity of the
+// runes of a and b: twice the number of runes in the longest common
+// substring and, recursively, in the common substrings to its left and to
+// its right, over their total length. It returns the same scores as
+// difflib.SequenceMatcher(None, a, b).ratio(), which, because of its junk
+// heuristic, isn't symmetric.
+func RatcliffObershelp(a, b string) float64 {
+	return RatcliffObershelpWith(RatcliffObershelpOptions{AutoJunk: true})(
+		a, b)
+}
+
+// RatcliffObershelpWith returns a RatcliffObershelp comparer configured by
+// o.
+func RatcliffObershelpWith(o RatcliffObershelpOptions) Comparer {
+	return func(a, b string) float64 {
+		ra, rb := Runes(a, b)
+		if len(ra)+len(rb) == 0 {
+			return 1.0
+		}
+		m := newMatcher(ra, rb, o)
+		return 2 * float64(m.matched(0, len(ra), 0, len(rb))) /
+			float64(len(ra)+len(rb))
+	}
+}
+
+// matcher is a port of difflib.SequenceMatcher.
+type matcher struct {
+	a, b []rune
+	// b2j are the positions of each rune in b that isn't junk or popular
+	b2j  map[rune][]int
+	junk map[rune]bool
+}
+
+func newMatcher(a, b []rune, o RatcliffObershelpOptions) matcher {
+	m := matcher{a: a, b: b, b2j: map[rune][]int{}, junk: map[rune]bool{}}
+	for j, r := range b {
+		m.b2j[r] = append(m.b2j[r], j)
+	}
+	if o.IsJunk != nil {
+		for r := range m.b2j {
+			if o.IsJunk(r) {
+				m.junk[r] = true
+				delete(m.b2j, r)
+			}
+		}
+	}
+	if o.AutoJunk && len(b) >= 200 {
+		popular := len(b)/100 + 1
+		for r, js := range m.b2j {
+			if len(js) > popular {
+				delete(m.b2j, r)
+			}
+		}
+	}
+	return m
+}
+
+// longest returns the longest matching block in a[alo:ahi] and b[blo:bhi]
+// as difflib's find_longest_match does.
+func (m matcher) longest(alo, ahi, blo, bhi int) (i, j, k int) {
+	a, b := m.a, m.b
+	i, j = alo, blo
+	j2len := map[int]int{}
+	for x := alo; x < ahi; x++ {
+		next := map[int]int{}
+		for _, y := range m.b2j[a[x]] {
+			if y < blo {
+				continue
+			}
+			if y >= bhi {
+				break
+			}
+			n := j2len[y-1] + 1
+			next[y] = n
+			if n > k {
+				i, j, k = x-n+1, y-n+1, n
+			}
+		}
+		j2len = next
+	}
+	// extend the match with popular runes, and then with junk
+	for _, junk := range []bool{false, true} {
+		for i > alo && j > blo && m.junk[b[j-1]] == junk &&
+			a[i-1] == b[j-1] {
+			i, j, k = i-1, j-1, k+1
+		}
+		for i+k < ahi && j+k < bhi && m.junk[b[j+k]] == junk &&
+			a[i+k] == b[j+k] {
+			k++
+		}
+	}
+	return i, j, k
+}
+
+// matched returns the total size of the matching blocks in a[alo:ahi] and
+// b[blo:bhi].
+func (m matcher) matched(alo, ahi, blo, bhi int) int {
+	i, j, k := m.longest(alo, ahi, blo, bhi)
+	if k == 0 {
+		return 0
+	}
+	n := k
+	if alo < i && blo < j {
+		n += m.matched(alo, i, blo, j)
+	}
+	if i+k < ahi && j+k < bhi {
+		n += m.matched(i+k, ahi, j+k, bhi)
+	}
+	return n
+}
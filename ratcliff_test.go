@@ -0,0 +1,59 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charles-haynes/strsim"
+)
+
+func TestRatcliffObershelp(t *testing.T) {
+	space := strsim.RatcliffObershelpWith(strsim.RatcliffObershelpOptions{
+		IsJunk: func(r rune) bool { return r == ' ' },
+	})
+	a := strings.Repeat("ab", 150)
+	b := strings.Repeat("aaab", 75)
+	// expected values are from Python's difflib.SequenceMatcher.ratio()
+	for _, c := range []struct {
+		f    strsim.Comparer
+		a, b string
+		e    float64
+	}{
+		{strsim.RatcliffObershelp, "abcd", "bcde", 0.75},
+		{space, "private Thread currentThread;",
+			"private volatile Thread currentThread;", 0.8656716417910447},
+		{strsim.RatcliffObershelp,
+			"Apotheosis, Vol. 1: Mozart - The Final Quartets",
+			"Mozart: The Final Quartets (apotheosis vol. 1)",
+			0.5376344086021505},
+		{strsim.RatcliffObershelp, a, b, 0.0033333333333333335},
+		{strsim.RatcliffObershelpWith(strsim.RatcliffObershelpOptions{}),
+			a, b, 0.7466666666666667},
+		{strsim.RatcliffObershelp, "Stéphane", "Stephane", 0.875},
+		{strsim.RatcliffObershelp, "", "", 1.0},
+	} {
+		if r := c.f(c.a, c.b); r != c.e {
+			t.Errorf("(%s,%s) = %v, expected %v", c.a, c.b, r, c.e)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Segmenter splits a and b into the units that the comparers operate on.
+// Each unit is represented by a single rune, and equal units in a and b are
+// represented by equal runes.
+type Segmenter func(a, b string) ([]rune, []rune)
+
+// Runes segments a and b into runes.
+func Runes(a, b string) ([]rune, []rune) {
+	return []rune(a), []rune(b)
+}
+
+// Graphemes segments a and b into extended grapheme clusters (UAX #29).
+// Clusters are compared in NFC, so decomposed and precomposed sequences
+// are the same unit.
+func Graphemes(a, b string) ([]rune, []rune) {
+	ids := map[string]rune{}
+	return graphemes(a, ids), graphemes(b, ids)
+}
+
+// graphemes returns one rune per cluster of s. Clusters of a single rune
+// are that rune, longer clusters are given ids past unicode.MaxRune.
+func graphemes(s string, ids map[string]rune) []rune {
+	r := make([]rune, 0, len(s))
+	g := uniseg.NewGraphemes(s)
+	for g.Next() {
+		c := norm.NFC.String(g.Str())
+		if utf8.RuneCountInString(c) == 1 {
+			u, _ := utf8.DecodeRuneInString(c)
+			r = append(r, u)
+			continue
+		}
+		id, ok := ids[c]
+		if !ok {
+			id = unicode.MaxRune + 1 + rune(len(ids))
+			ids[c] = id
+		}
+		r = append(r, id)
+	}
+	return r
+}
+
+// NewJaroWinkler returns a JaroWinkler comparer over the units of seg.
+func NewJaroWinkler(seg Segmenter) Comparer {
+	return func(a, b string) float64 {
+		return jaroWinkler(seg(a, b))
+	}
+}
+
+// NewLCS returns an LCS comparer over the units of seg.
+func NewLCS(seg Segmenter) Comparer {
+	return func(a, b string) float64 {
+		return lcsRatio(seg(a, b))
+	}
+}
+
+// NewCommonTrigrams returns a CommonTrigrams comparer over the units of seg.
+func NewCommonTrigrams(seg Segmenter) Comparer {
+	return func(a, b string) float64 {
+		return commonTrigrams(seg(a, b))
+	}
+}
+
+// NewLevenshein returns a Levenshein comparer over the units of seg.
+func NewLevenshein(seg Segmenter) Comparer {
+	return func(a, b string) float64 {
+		return levenshein(seg(a, b))
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim_test
+
+import (
+	"testing"
+
+	"github.com/charles-haynes/strsim"
+)
+
+var GraphemeSims = map[string]strsim.Comparer{
+	"levenshein":      strsim.NewLevenshein(strsim.Graphemes),
+	"jaro-winkler":    strsim.NewJaroWinkler(strsim.Graphemes),
+	"lcs":             strsim.NewLCS(strsim.Graphemes),
+	"common trigrams": strsim.NewCommonTrigrams(strsim.Graphemes),
+}
+
+func TestGraphemes(t *testing.T) {
+	for _, c := range [][]string{
+		{"Ste\u0301phane", "St\u00e9phane"},
+		{"Formations Magne\u0301tiques", "Formations Magn\u00e9tiques"},
+		{"\u1100\u1161\u11a8 \u1100\u1161", "\uac01 \uac00"},
+	} {
+		for s, f := range GraphemeSims {
+			if r := f(c[0], c[1]); r != 1.0 {
+				t.Errorf("%s(%q,%q) = %5.3f, expected 1.0", s, c[0], c[1], r)
+			}
+		}
+	}
+	family := "\U0001F468‍\U0001F469‍\U0001F467"
+	for s, f := range GraphemeSims {
+		r := f("Stephane"+family, "Stephane!")
+		e := f("Stephane?", "Stephane!")
+		if r != e {
+			t.Errorf("%s(Stephane%s,Stephane!) = %5.3f, expected %5.3f",
+				s, family, r, e)
+		}
+	}
+}
@@ -21,127 +21,229 @@
 package strsim
 
 import (
-	"strings"
-
-	"github.com/xrash/smetrics"
+	"math"
 )
 
 type Comparer = func(a, b string) float64
 
-const shortestSubStrLen = 3
-
-type lcs struct {
-	lengths    [][]int
-	aMap, bMap []int
-	length     int
-	aIndex     int
-	bIndex     int
+// compareParts returns f(a, b), or 1.0 if a and b are both empty, which
+// some comparers score as NaN or 0. It is for comparing what is left of
+// strings after parsing part of them out.
+func compareParts(f Comparer, a, b string) float64 {
+	if a == "" && b == "" {
+		return 1.0
+	}
+	return f(a, b)
 }
 
-func newLCS(a, b string) lcs {
-	l := lcs{
-		lengths: make([][]int, len(a)),
-		aMap:    make([]int, len(a)),
-		bMap:    make([]int, len(b)),
-	}
-	for i := range l.aMap {
-		l.aMap[i] = i
-	}
-	for i := range l.bMap {
-		l.bMap[i] = i
-	}
-	for i := range l.lengths {
-		l.lengths[i] = make([]int, len(b))
-		for j := range l.lengths[i] {
-			if a[i] != b[j] {
-				continue
-			}
-			if i == 0 || j == 0 {
-				l.lengths[i][j] = 1
-			} else {
-				l.lengths[i][j] = l.lengths[i-1][j-1] + 1
-			}
-			if l.lengths[i][j] > l.length {
-				l.length = l.lengths[i][j]
-				l.aIndex = i + 1
-				l.bIndex = j + 1
-			}
-		}
-	}
-	return l
-}
-
-func (l *lcs) next() {
-	ml := 0
-	ai := 0
-	bi := 0
-	l.lengths = append(l.lengths[:l.aIndex-l.length],
-		l.lengths[l.aIndex:]...)
-	amai := l.aMap[l.aIndex-1] + 1
-	bmbi := l.bMap[l.bIndex-1] + 1
-	l.aMap = append(l.aMap[:l.aIndex-l.length], l.aMap[l.aIndex:]...)
-	l.bMap = append(l.bMap[:l.bIndex-l.length], l.bMap[l.bIndex:]...)
-	for i := range l.lengths {
-		l.lengths[i] = append(l.lengths[i][:l.bIndex-l.length],
-			l.lengths[i][l.bIndex:]...)
-		for j := range l.lengths[i] {
-			if l.aMap[i] >= amai {
-				if l.aMap[i]-l.lengths[i][j] < amai {
-					l.lengths[i][j] = l.aMap[i] - amai + 1
-				}
-			}
-			if l.bMap[j] >= bmbi {
-				if l.bMap[j]-l.lengths[i][j] < bmbi {
-					l.lengths[i][j] = l.bMap[j] - bmbi + 1
-				}
-			}
-			if l.lengths[i][j] > ml {
-				ml = l.lengths[i][j]
-				ai = i + 1
-				bi = j + 1
-			}
-		}
-	}
-	l.length = ml
-	l.aIndex = ai
-	l.bIndex = bi
+const shortestSubStrLen = 3
+
+func subStrLen(a, b []rune) int {
+	return tiledLen(a, b, shortestSubStrLen)
 }
 
-func subStrLen(a, b string) int {
-	if len(a) < shortestSubStrLen || len(b) < shortestSubStrLen {
-		if a == b {
+// tiledLen returns the total length of the common substrings of a and b,
+// found longest first, that are at least min runes long.
+func tiledLen(a, b []rune, min int) int {
+	if len(a) < min || len(b) < min {
+		if equalRunes(a, b) {
 			return len(a)
 		}
 		return 0
 	}
 	r := 0
-	for l := newLCS(a, b); l.length >= shortestSubStrLen; l.next() {
-		r += l.length
-	}
+	forTiles(a, b, min, func(l, _, _ int) {
+		r += l
+	})
 	return r
 }
 
-func CommonTrigrams(a, b string) float64 {
+func equalRunes(a, b []rune) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func commonTrigrams(a, b []rune) float64 {
+	return boundedTrigrams(a, b, 0.0)
+}
+
+// boundedTrigrams is commonTrigrams, but returns 0.0 as soon as the result
+// can't be at least bound.
+func boundedTrigrams(a, b []rune, bound float64) float64 {
 	if len(a) < 3 || len(b) < 3 {
-		if a == b {
+		if equalRunes(a, b) {
 			return 1.0
 		}
 		return 0.0
 	}
-	tg := map[string]int{}
+	if trigramBound(len(a)-2, len(b)-2, len(b)-2) < bound {
+		return 0.0
+	}
+	tg := map[[3]rune]int{}
 	for i := 3; i <= len(a); i++ {
-		tg[a[i-3:i]]++
+		tg[[3]rune{a[i-3], a[i-2], a[i-1]}]++
 	}
 	c := 0
 	for i := 3; i <= len(b); i++ {
-		if tg[b[i-3:i]] > 0 {
+		if trigramBound(len(a)-2, len(b)-2, c+len(b)-i+1) < bound {
+			return 0.0
+		}
+		k := [3]rune{b[i-3], b[i-2], b[i-1]}
+		if tg[k] > 0 {
 			c++
-			tg[b[i-3:i]]--
+			tg[k]--
 		}
 	}
 	return float64(c) / float64(len(a)-2+len(b)-2-c)
 }
 
+// trigramBound returns the largest commonTrigrams score for na and nb
+// trigrams if at most c are common.
+func trigramBound(na, nb, c int) float64 {
+	if c > na {
+		c = na
+	}
+	if c > nb {
+		c = nb
+	}
+	return float64(c) / float64(na+nb-c)
+}
+
+// levenshein counts a substitution as a deletion and an insertion, so the
+// distance is the number of runes not in the longest common subsequence.
+func levenshein(a, b []rune) float64 {
+	if len(a)+len(b) == 0 {
+		return 1.0
+	}
+	return 1.0 - float64(len(a)+len(b)-2*bitLCS(a, b))/
+		(float64(len(a)+len(b)))
+}
+
+// jaro follows smetrics.Jaro, including its match window, so that scores
+// for ASCII input are unchanged. It returns 0 as soon as the result can't
+// be at least need.
+func jaro(a, b []rune, need float64) float64 {
+	la, lb := len(a), len(b)
+	matchRange := la
+	if lb > matchRange {
+		matchRange = lb
+	}
+	matchRange = matchRange/2 - 2
+	if matchRange < 0 {
+		matchRange = 0
+	}
+	var matches, halfs float64
+	transposed := make([]bool, lb)
+	for i := 0; i < la; i++ {
+		if jaroBound(la, lb, int(matches)+la-i) < need {
+			return 0
+		}
+		start := i - matchRange
+		if start < 0 {
+			start = 0
+		}
+		end := i + matchRange
+		if end > lb-1 {
+			end = lb - 1
+		}
+		for j := start; j <= end; j++ {
+			if transposed[j] {
+				continue
+			}
+			if a[i] == b[j] {
+				if i != j {
+					halfs++
+				}
+				matches++
+				transposed[j] = true
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+	transposes := math.Floor(halfs / 2)
+	return (matches/float64(la) + matches/float64(lb) +
+		(matches-transposes)/matches) / 3.0
+}
+
+// jaroBound returns the largest jaro score for strings of length la and
+// lb if at most m runes match.
+func jaroBound(la, lb, m int) float64 {
+	if m > la {
+		m = la
+	}
+	if m > lb {
+		m = lb
+	}
+	if m == 0 {
+		return 0
+	}
+	return (float64(m)/float64(la) + float64(m)/float64(lb) + 1) / 3.0
+}
+
+const boostThreshold, prefixSize = 0.7, 4
+
+func jaroWinkler(a, b []rune) float64 {
+	return boundedJaroWinkler(a, b, 0.0)
+}
+
+// boundedJaroWinkler is jaroWinkler, but returns 0.0 as soon as the
+// result can't be at least bound.
+func boundedJaroWinkler(a, b []rune, bound float64) float64 {
+	p := prefixSize
+	if len(a) < p {
+		p = len(a)
+	}
+	if len(b) < p {
+		p = len(b)
+	}
+	// the smallest jaro score that can be boosted to bound
+	need := (bound - 0.1*float64(p)) / (1 - 0.1*float64(p))
+	if need < boostThreshold {
+		need = boostThreshold
+	}
+	if need > bound {
+		need = bound
+	}
+	j := jaro(a, b, need)
+	if j <= boostThreshold {
+		if j < bound {
+			return 0
+		}
+		return j
+	}
+	prefixMatch := 0.0
+	for i := 0; i < p; i++ {
+		if a[i] == b[i] {
+			prefixMatch++
+		}
+	}
+	if r := j + 0.1*prefixMatch*(1.0-j); r >= bound {
+		return r
+	}
+	return 0
+}
+
+func lcsRatio(a, b []rune) float64 {
+	s := subStrLen(a, b)
+	return float64(s) / float64(len(a)+len(b)-s)
+}
+
+// CommonTrigrams returns the proportion of trigrams of runes that a and b
+// have in common.
+func CommonTrigrams(a, b string) float64 {
+	return commonTrigrams(Runes(a, b))
+}
+
 func StringCompare(a, b string) float64 {
 	if a == b {
 		return 1.0
@@ -149,27 +251,28 @@ func StringCompare(a, b string) float64 {
 	return 0.0
 }
 
+// Levenshein returns the edit distance between the runes of a and b,
+// counting a substitution as a deletion and an insertion, normalized to
+// [0.0..1.0]. Two empty strings score 1.0.
 func Levenshein(a, b string) float64 {
-	return 1.0 - float64(smetrics.WagnerFischer(a, b, 1, 1, 2))/
-		(float64(len(a)+len(b)))
+	return levenshein(Runes(a, b))
 }
 
+// JaroWinkler returns the Jaro-Winkler similarity of the runes of a and b.
 func JaroWinkler(a, b string) float64 {
-	return smetrics.JaroWinkler(a, b, 0.7, 4)
+	return jaroWinkler(Runes(a, b))
 }
 
+// LCS returns the total length of the common substrings of runes of a and
+// b, found longest first, as a proportion of their combined length.
 func LCS(a, b string) float64 {
-	s := subStrLen(a, b)
-	return float64(s) / float64(len(a)+len(b)-s)
+	return lcsRatio(Runes(a, b))
 }
 
 // WrapNoCase take a comparer and returns a comparer that does a case
 // insensitive comparison
 func WrapNoCase(f Comparer) Comparer {
-	return func(a, b string) float64 {
-		return f(strings.ToLower(a), strings.ToLower(b))
-	}
-
+	return Wrap(Lower, f)
 }
 
 // ListSimilarity compares all the as and bs and returns the max similarity
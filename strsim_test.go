@@ -23,11 +23,14 @@ package strsim_test
 import (
 	"fmt"
 	"math"
+	"math/rand"
 	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/charles-haynes/strsim"
+	"github.com/xrash/smetrics"
 )
 
 var (
@@ -38,19 +41,40 @@ var (
 		"not equal":      {aTest[0:13], bTest[0:13]},
 		"almost equal":   {aTest[0:7], aTest[0:3] + "!" + aTest[3:6]},
 		"mostly unequal": {aTest[0:9], bTest[0:3] + aTest[3:6] + bTest[6:9]},
+		"long": {strings.Repeat(aTest, 8),
+			strings.Repeat(aTest[0:13]+bTest[0:13], 8)},
 	}
 
 	Sims = map[string]func(a, b string) float64{
-		"string compare":  strsim.WrapNoCase(strsim.StringCompare),
-		"levenshein":      strsim.WrapNoCase(strsim.Levenshein),
-		"jaro-winkler":    strsim.WrapNoCase(strsim.JaroWinkler),
-		"lcs":             strsim.WrapNoCase(strsim.LCS),
-		"common trigrams": strsim.WrapNoCase(strsim.CommonTrigrams),
+		"string compare":   strsim.WrapNoCase(strsim.StringCompare),
+		"levenshein":       strsim.WrapNoCase(strsim.Levenshein),
+		"jaro-winkler":     strsim.WrapNoCase(strsim.JaroWinkler),
+		"lcs":              strsim.WrapNoCase(strsim.LCS),
+		"common trigrams":  strsim.WrapNoCase(strsim.CommonTrigrams),
+		"unit levenshtein": strsim.WrapNoCase(strsim.UnitLevenshtein),
+		"ratcliff-obershelp": strsim.WrapNoCase(
+			strsim.RatcliffObershelp),
+		"lcsubsequence": strsim.WrapNoCase(strsim.LCSubsequence),
+	}
+
+	// Baselines are only benchmarked
+	Baselines = map[string]func(a, b string) float64{
+		"smetrics wagner-fischer": func(a, b string) float64 {
+			return 1.0 - float64(smetrics.WagnerFischer(a, b, 1, 1, 2))/
+				float64(len(a)+len(b))
+		},
 	}
 )
 
 func BenchmarkAll(b *testing.B) {
+	all := map[string]func(a, b string) float64{}
 	for s, f := range Sims {
+		all[s] = f
+	}
+	for s, f := range Baselines {
+		all[s] = f
+	}
+	for s, f := range all {
 		for c, v := range Values {
 			b.Run(s+": "+c, func(b *testing.B) {
 				for i := 0; i < b.N; i++ {
@@ -100,6 +124,127 @@ func TestCompare(t *testing.T) {
 	}
 }
 
+func TestRunes(t *testing.T) {
+	// An accented rune must cost the same as any other single rune.
+	for s, f := range Sims {
+		r := f("Stéphane", "Stephane")
+		e := f("Stxphane", "Stephane")
+		if r != e {
+			t.Errorf("%s(Stéphane,Stephane) = %5.3f, expected %5.3f", s, r, e)
+		}
+	}
+	for _, c := range []struct {
+		a, b string
+		f    strsim.Comparer
+		e    float64
+	}{
+		{"레드벨벳", "레드벨벳", strsim.CommonTrigrams, 1.0},
+		{"레드벨벳", "레드벨", strsim.CommonTrigrams, 0.5},
+		{"Magnétiques", "Magnetiques", strsim.Levenshein, 1.0 - 2.0/22.0},
+		{"éé", "éé", strsim.LCS, 1.0},
+		{"ééé", "éée", strsim.LCS, 0.0},
+	} {
+		if r := c.f(c.a, c.b); math.Abs(r-c.e) > 1e-9 {
+			t.Errorf("(%s,%s) = %5.3f, expected %5.3f", c.a, c.b, r, c.e)
+		}
+	}
+}
+
+func TestLCS(t *testing.T) {
+	for _, c := range []struct {
+		a, b string
+		s, n float64
+	}{
+		{"Apotheosis, Vol. 1: Mozart - The Final Quartets",
+			"Mozart: The Final Quartets (apotheosis vol. 1)", 39, 93},
+		{"Beck-Ola", "Truth/Beck-Ola", 8, 22},
+		{"abcabcabc", "cbacbacba", 0, 18},
+		{"Nick Cave & The Bad Seeds", "Nick Cave and The Bad Seeds", 24, 52},
+		{"aaaabaaaa", "aaaaaaaab", 9, 18},
+	} {
+		if r := strsim.LCS(c.a, c.b); r != c.s/(c.n-c.s) {
+			t.Errorf("LCS(%s,%s) = %5.3f, expected %5.3f",
+				c.a, c.b, r, c.s/(c.n-c.s))
+		}
+	}
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+func TestASCIIUnchanged(t *testing.T) {
+	for _, n := range GroupsEqual {
+		if !isASCII(n[0]) || !isASCII(n[1]) {
+			continue
+		}
+		l := 1.0 - float64(smetrics.WagnerFischer(n[0], n[1], 1, 1, 2))/
+			float64(len(n[0])+len(n[1]))
+		if r := strsim.Levenshein(n[0], n[1]); r != l {
+			t.Errorf("Levenshein(%s,%s) = %5.3f, expected %5.3f",
+				n[0], n[1], r, l)
+		}
+		j := smetrics.JaroWinkler(n[0], n[1], 0.7, 4)
+		if r := strsim.JaroWinkler(n[0], n[1]); r != j {
+			t.Errorf("JaroWinkler(%s,%s) = %5.3f, expected %5.3f",
+				n[0], n[1], r, j)
+		}
+	}
+}
+
+func randomString(r *rand.Rand, n int) string {
+	const alphabet = "abcdeé你"
+	rs := []rune(alphabet)
+	s := make([]rune, n)
+	for i := range s {
+		s[i] = rs[r.Intn(len(rs))]
+	}
+	return string(s)
+}
+
+func TestBitParallel(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		a := randomString(r, r.Intn(300))
+		b := randomString(r, r.Intn(300))
+		ra, rb := []rune(a), []rune(b)
+		// smetrics works on bytes, so give it one byte per rune
+		ba, bb := strings.Map(latin1, a), strings.Map(latin1, b)
+		d := smetrics.WagnerFischer(ba, bb, 1, 1, 2)
+		e := 1.0 - float64(d)/float64(len(ra)+len(rb))
+		if len(ra)+len(rb) == 0 {
+			continue
+		}
+		if s := strsim.Levenshein(a, b); s != e {
+			t.Errorf("Levenshein(%s,%s) = %f, expected %f", a, b, s, e)
+		}
+		l := len(ra)
+		if len(rb) > l {
+			l = len(rb)
+		}
+		d = smetrics.WagnerFischer(ba, bb, 1, 1, 1)
+		e = 1.0 - float64(d)/float64(l)
+		if s := strsim.UnitLevenshtein(a, b); s != e {
+			t.Errorf("UnitLevenshtein(%s,%s) = %f, expected %f", a, b, s, e)
+		}
+	}
+}
+
+func latin1(r rune) rune {
+	switch r {
+	case 'é':
+		return 'x'
+	case '你':
+		return 'y'
+	}
+	return r
+}
+
 func TestRealWorld(t *testing.T) {
 	max := 0.0
 	maxSim := ""
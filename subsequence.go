@@ -0,0 +1,132 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim
+
+// LCSubsequence returns the length of the longest common subsequence of
+// the runes of a and b, which unlike the substrings LCS finds need not be
+// contiguous, as a proportion of their combined length less it. Two empty
+// strings score 1.0.
+func LCSubsequence(a, b string) float64 {
+	return LCSubsequenceWith(JaccardRatio)(a, b)
+}
+
+// LCSubsequenceWith returns an LCSubsequence comparer that turns the
+// length of the longest common subsequence into a score using r. With
+// MinLengthRatio an abbreviation scores 1.0 against anything it is a
+// subsequence of.
+func LCSubsequenceWith(r Ratio) Comparer {
+	return func(a, b string) float64 {
+		ra, rb := Runes(a, b)
+		return r.score(bitLCS(ra, rb), len(ra), len(rb))
+	}
+}
+
+// Alignment is a longest common subsequence of two strings, and the
+// indexes of its runes in each of them.
+type Alignment struct {
+	Subsequence    string
+	AIndex, BIndex []int
+}
+
+// AlignSubsequence returns a longest common subsequence of the runes of a
+// and b, and where its runes are in each, using Hirschberg's algorithm in
+// space linear in the length of b.
+func AlignSubsequence(a, b string) Alignment {
+	ra, rb := Runes(a, b)
+	h := hirschberg{
+		row1: make([]int, len(rb)+1),
+		row2: make([]int, len(rb)+1),
+	}
+	h.align(ra, rb, 0, 0)
+	s := make([]rune, len(h.aIndex))
+	for i, x := range h.aIndex {
+		s[i] = ra[x]
+	}
+	return Alignment{string(s), h.aIndex, h.bIndex}
+}
+
+type hirschberg struct {
+	row1, row2     []int
+	aIndex, bIndex []int
+}
+
+// align appends the alignment of a and b, which start at aOff and bOff in
+// the original strings.
+func (h *hirschberg) align(a, b []rune, aOff, bOff int) {
+	if len(a) == 0 || len(b) == 0 {
+		return
+	}
+	if len(a) == 1 {
+		for j, r := range b {
+			if r == a[0] {
+				h.aIndex = append(h.aIndex, aOff)
+				h.bIndex = append(h.bIndex, bOff+j)
+				return
+			}
+		}
+		return
+	}
+	mid := len(a) / 2
+	fwd := h.lengths(a[:mid], b, false)
+	fwd = append([]int(nil), fwd...)
+	rev := h.lengths(a[mid:], b, true)
+	best, k := -1, 0
+	for j := 0; j <= len(b); j++ {
+		if l := fwd[j] + rev[len(b)-j]; l > best {
+			best, k = l, j
+		}
+	}
+	h.align(a[:mid], b[:k], aOff, bOff)
+	h.align(a[mid:], b[k:], aOff+mid, bOff+k)
+}
+
+// lengths returns the last row of the longest common subsequence length
+// matrix of a and b, so that element j is the length for a and the first j
+// runes of b. If reverse it is for a and b reversed.
+func (h *hirschberg) lengths(a, b []rune, reverse bool) []int {
+	prev, cur := h.row1[:len(b)+1], h.row2[:len(b)+1]
+	for j := range prev {
+		prev[j] = 0
+	}
+	for i := range a {
+		x := a[i]
+		if reverse {
+			x = a[len(a)-1-i]
+		}
+		cur[0] = 0
+		for j := 1; j <= len(b); j++ {
+			y := b[j-1]
+			if reverse {
+				y = b[len(b)-j]
+			}
+			switch {
+			case x == y:
+				cur[j] = prev[j-1] + 1
+			case prev[j] >= cur[j-1]:
+				cur[j] = prev[j]
+			default:
+				cur[j] = cur[j-1]
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev
+}
@@ -0,0 +1,95 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/charles-haynes/strsim"
+)
+
+func TestLCSubsequence(t *testing.T) {
+	for _, c := range []struct {
+		f    strsim.Comparer
+		a, b string
+		e    float64
+	}{
+		{strsim.LCSubsequence, "ABCBDAB", "BDCABA", 4.0 / 9},
+		{strsim.LCSubsequence, "FRKWYS", "FRKWYS Vol. 15", 6.0 / 14},
+		{strsim.LCSubsequenceWith(strsim.MinLengthRatio), "FRKWYS",
+			"FRKWYS Vol. 15", 1.0},
+		{strsim.LCSubsequenceWith(strsim.MinLengthRatio), "JAB",
+			"John Also Bennett", 1.0},
+		{strsim.LCSubsequenceWith(strsim.DiceRatio), "Stéphane",
+			"Stephane", 14.0 / 16},
+		{strsim.LCSubsequence, "", "", 1.0},
+		{strsim.LCSubsequence, "", "abc", 0.0},
+		{strsim.LCSubsequenceWith(strsim.MinLengthRatio), "", "", 1.0},
+		{strsim.LCSubsequenceWith(strsim.MinLengthRatio), "", "abc", 0.0},
+		{strsim.LCSubsequenceWith(strsim.DiceRatio), "", "", 1.0},
+	} {
+		if r := c.f(c.a, c.b); math.Abs(r-c.e) > 1e-9 {
+			t.Errorf("(%s,%s) = %5.3f, expected %5.3f", c.a, c.b, r, c.e)
+		}
+	}
+}
+
+// checkAlignment checks that al is a common subsequence of a and b of
+// length n.
+func checkAlignment(t *testing.T, a, b string, al strsim.Alignment, n int) {
+	ra, rb, rs := []rune(a), []rune(b), []rune(al.Subsequence)
+	if len(rs) != n || len(al.AIndex) != n || len(al.BIndex) != n {
+		t.Errorf("AlignSubsequence(%s,%s) = %+v, expected length %d",
+			a, b, al, n)
+		return
+	}
+	for i := range rs {
+		if ra[al.AIndex[i]] != rs[i] || rb[al.BIndex[i]] != rs[i] ||
+			i > 0 && (al.AIndex[i] <= al.AIndex[i-1] ||
+				al.BIndex[i] <= al.BIndex[i-1]) {
+			t.Errorf("AlignSubsequence(%s,%s) = %+v, not aligned",
+				a, b, al)
+			return
+		}
+	}
+}
+
+func TestAlignSubsequence(t *testing.T) {
+	al := strsim.AlignSubsequence("FRKWYS", "FRKWYS Vol. 15")
+	checkAlignment(t, "FRKWYS", "FRKWYS Vol. 15", al, 6)
+	if al.BIndex[5] != 5 {
+		t.Errorf("AlignSubsequence(FRKWYS,FRKWYS Vol. 15) = %+v", al)
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		a := randomString(r, r.Intn(50))
+		b := randomString(r, r.Intn(50))
+		ra, rb := []rune(a), []rune(b)
+		n := int(math.Round(strsim.LCSubsequenceWith(strsim.DiceRatio)(
+			a, b) * float64(len(ra)+len(rb)) / 2))
+		if len(ra)+len(rb) == 0 {
+			n = 0
+		}
+		checkAlignment(t, a, b, strsim.AlignSubsequence(a, b), n)
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"sort"
+)
+
+// DocFreq is the number of documents in a corpus, and how many of them each
+// term occurs in. Its fields are exported so that it can be saved once
+// fitted, for example with encoding/json.
+type DocFreq struct {
+	N     int            `json:"n"`
+	Terms map[string]int `json:"terms"`
+}
+
+// Add counts a document with the given terms.
+func (d *DocFreq) Add(terms []string) {
+	if d.Terms == nil {
+		d.Terms = map[string]int{}
+	}
+	d.N++
+	seen := make(map[string]bool, len(terms))
+	for _, t := range terms {
+		if !seen[t] {
+			seen[t] = true
+			d.Terms[t]++
+		}
+	}
+}
+
+// IDF returns the smoothed inverse document frequency of term, which is
+// 1.0 for a term in every document and largest for a term in none.
+func (d *DocFreq) IDF(term string) float64 {
+	return math.Log(float64(d.N+1)/float64(d.Terms[term]+1)) + 1
+}
+
+// weights returns the distinct terms in sorted order and their tf-idf
+// weights, scaled to unit length.
+func (d *DocFreq) weights(terms []string) ([]string, []float64) {
+	tf := map[string]int{}
+	for _, t := range terms {
+		tf[t]++
+	}
+	ts := make([]string, 0, len(tf))
+	for t := range tf {
+		ts = append(ts, t)
+	}
+	sort.Strings(ts)
+	ws := make([]float64, len(ts))
+	norm := 0.0
+	for i, t := range ts {
+		ws[i] = float64(tf[t]) * d.IDF(t)
+		norm += ws[i] * ws[i]
+	}
+	norm = math.Sqrt(norm)
+	for i := range ws {
+		ws[i] /= norm
+	}
+	return ts, ws
+}
+
+// addLines calls add with each line read from r.
+func addLines(r io.Reader, add func(s string)) error {
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		add(s.Text())
+	}
+	return s.Err()
+}
+
+// SoftTFIDF is a model of how common each token is in a corpus, used to
+// compare strings so that tokens common in the corpus, such as "vol", "the"
+// or "live" in release titles, count for less than rare ones.
+type SoftTFIDF struct {
+	DocFreq DocFreq `json:"docFreq"`
+}
+
+// NewSoftTFIDF returns a SoftTFIDF model fitted to corpus.
+func NewSoftTFIDF(corpus []string) *SoftTFIDF {
+	m := &SoftTFIDF{}
+	for _, s := range corpus {
+		m.Add(s)
+	}
+	return m
+}
+
+// Add adds the tokens of s to the model.
+func (m *SoftTFIDF) Add(s string) {
+	m.DocFreq.Add(Tokens(s))
+}
+
+// AddLines adds each line read from r to the model.
+func (m *SoftTFIDF) AddLines(r io.Reader) error {
+	return addLines(r, m.Add)
+}
+
+// Comparer returns a comparer of the soft tf-idf similarity of a and b, the
+// cosine of their token weights where each token of a is matched with the
+// token of b it scores best against with inner, if that score is at least
+// threshold, and counts for that score. With StringCompare it is the tf-idf
+// cosine of the tokens. It is not symmetric, and the model must not be
+// changed while it is in use.
+func (m *SoftTFIDF) Comparer(inner Comparer, threshold float64) Comparer {
+	return func(a, b string) float64 {
+		ta, wa := m.DocFreq.weights(Tokens(a))
+		tb, wb := m.DocFreq.weights(Tokens(b))
+		if len(ta) == 0 || len(tb) == 0 {
+			if len(ta) == len(tb) {
+				return 1.0
+			}
+			return 0.0
+		}
+		sim := 0.0
+		for i, x := range ta {
+			best, k := 0.0, -1
+			for j, y := range tb {
+				if s := inner(x, y); s > best {
+					best, k = s, j
+				}
+			}
+			if k >= 0 && best >= threshold {
+				sim += wa[i] * wb[k] * best
+			}
+		}
+		return math.Min(sim, 1.0)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim_test
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/charles-haynes/strsim"
+)
+
+var catalog = []string{
+	"Live at Leeds",
+	"Live at the Apollo",
+	"Live at Budokan",
+	"Live at Montreux",
+	"Apotheosis, Vol. 1",
+	"Jazz Club, Vol. 2",
+	"The Final Quartets",
+	"Umm Kulthum Live",
+}
+
+func TestDocFreq(t *testing.T) {
+	var d strsim.DocFreq
+	d.Add([]string{"live", "at", "live"})
+	d.Add([]string{"live"})
+	if d.N != 2 || d.Terms["live"] != 2 || d.Terms["at"] != 1 {
+		t.Errorf("DocFreq = %+v", d)
+	}
+	if r := d.IDF("live"); r != 1.0 {
+		t.Errorf("IDF(live) = %5.3f, expected 1.000", r)
+	}
+	if d.IDF("leeds") <= d.IDF("at") {
+		t.Errorf("IDF(leeds) = %5.3f, not more than IDF(at) = %5.3f",
+			d.IDF("leeds"), d.IDF("at"))
+	}
+}
+
+func TestSoftTFIDF(t *testing.T) {
+	m := strsim.NewSoftTFIDF(catalog)
+	f := m.Comparer(strsim.JaroWinkler, 0.85)
+	for _, c := range []struct {
+		a, b string
+		e    float64
+	}{
+		{"Live at Leeds", "Live at Leeds", 1.0},
+		{"", "", 1.0},
+		{"", "Live", 0.0},
+	} {
+		if r := f(c.a, c.b); math.Abs(r-c.e) > 1e-9 {
+			t.Errorf("SoftTFIDF(%s,%s) = %5.3f, expected %5.3f",
+				c.a, c.b, r, c.e)
+		}
+	}
+	var empty strsim.SoftTFIDF
+	if r := empty.Comparer(strsim.StringCompare, 0.5)("a b", "a c"); math.Abs(r-0.5) > 1e-9 {
+		t.Errorf("SoftTFIDF(a b,a c) = %5.3f, expected 0.500", r)
+	}
+	plain := empty.Comparer(strsim.JaroWinkler, 0.85)
+	if r, e := f("Live at Leeds", "Live at Budokan"),
+		plain("Live at Leeds", "Live at Budokan"); r >= e {
+		t.Errorf("SoftTFIDF(Live at Leeds,Live at Budokan) = %5.3f, "+
+			"expected less than untrained %5.3f", r, e)
+	}
+	exact := m.Comparer(strsim.StringCompare, 0.85)
+	if r, e := f("Umm Kalthoum Live", "Umm Kulthum Live"),
+		exact("Umm Kalthoum Live", "Umm Kulthum Live"); r <= e {
+		t.Errorf("SoftTFIDF(Umm Kalthoum Live,Umm Kulthum Live) = %5.3f, "+
+			"expected more than exact %5.3f", r, e)
+	}
+}
+
+func TestSoftTFIDFFit(t *testing.T) {
+	m := strsim.NewSoftTFIDF(catalog)
+	var r strsim.SoftTFIDF
+	if err := r.AddLines(strings.NewReader(strings.Join(catalog, "\n"))); err != nil {
+		t.Fatalf("AddLines: %v", err)
+	}
+	j, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var u strsim.SoftTFIDF
+	if err := json.Unmarshal(j, &u); err != nil {
+		t.Fatalf("Unmarshal(%s): %v", j, err)
+	}
+	e := m.Comparer(strsim.JaroWinkler, 0.9)
+	for _, n := range GroupsEqual {
+		s := e(n[0], n[1])
+		if a := r.Comparer(strsim.JaroWinkler, 0.9)(n[0], n[1]); a != s {
+			t.Errorf("AddLines SoftTFIDF(%s,%s) = %5.3f, expected %5.3f",
+				n[0], n[1], a, s)
+		}
+		if a := u.Comparer(strsim.JaroWinkler, 0.9)(n[0], n[1]); a != s {
+			t.Errorf("Unmarshaled SoftTFIDF(%s,%s) = %5.3f, expected %5.3f",
+				n[0], n[1], a, s)
+		}
+	}
+}
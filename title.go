@@ -0,0 +1,164 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim
+
+import (
+	"math/bits"
+	"regexp"
+	"strings"
+)
+
+// Kind is a set of flags describing the edition or version of a release.
+type Kind uint
+
+const (
+	EP Kind = 1 << iota
+	LP
+	Single
+	Remix
+	Live
+	Soundtrack
+	Deluxe
+	Remaster
+	Instrumental
+	Acoustic
+	Demo
+)
+
+var kindWords = map[string]Kind{
+	"ep":            EP,
+	"lp":            LP,
+	"single":        Single,
+	"remix":         Remix,
+	"remixes":       Remix,
+	"remixed":       Remix,
+	"mix":           Remix,
+	"rmx":           Remix,
+	"edit":          Remix,
+	"dub":           Remix,
+	"live":          Live,
+	"soundtrack":    Soundtrack,
+	"ost":           Soundtrack,
+	"score":         Soundtrack,
+	"deluxe":        Deluxe,
+	"expanded":      Deluxe,
+	"anniversary":   Deluxe,
+	"remaster":      Remaster,
+	"remastered":    Remaster,
+	"instrumental":  Instrumental,
+	"instrumentals": Instrumental,
+	"acoustic":      Acoustic,
+	"demo":          Demo,
+	"demos":         Demo,
+}
+
+// Title is a release title split into its base title and the qualifiers
+// that describe which edition or version of it this is.
+type Title struct {
+	Base       string
+	Qualifiers []string
+	Kind       Kind
+}
+
+// kindOf returns the Kind of the words of s.
+func kindOf(s string) Kind {
+	var k Kind
+	for _, w := range Tokens(s) {
+		k |= kindWords[w]
+	}
+	return k
+}
+
+var (
+	// a bracketed group
+	bracketed = regexp.MustCompile(`\s*[(\[（［]([^)\]）］]*)[)\]）］]?`)
+	// a qualifier following a dash at the end of the title
+	dashed = regexp.MustCompile(`\s+[-–—]\s+([^-–—]+)$`)
+	// an unbracketed qualifier at the end of the title
+	trailing = regexp.MustCompile(`(?i)\s+((?:original\s+)?(?:motion\s+picture\s+)?soundtrack|ost|ep|lp|single|remixes|live!?|deluxe(?:\s+edition)?)$`)
+)
+
+// ParseTitle splits a release title such as "Corail (Remixed)" or
+// "Mystic Warrior EP" into its base title and qualifiers. Bracketed or
+// dashed parts of the title are only qualifiers if they contain a word
+// that gives the Kind of release.
+func ParseTitle(s string) Title {
+	var t Title
+	s = bracketed.ReplaceAllStringFunc(s, func(g string) string {
+		q := strings.TrimSpace(bracketed.FindStringSubmatch(g)[1])
+		if k := kindOf(q); k != 0 {
+			t.Qualifiers = append(t.Qualifiers, q)
+			t.Kind |= k
+			return ""
+		}
+		return g
+	})
+	for {
+		m := dashed.FindStringSubmatchIndex(s)
+		if m == nil || kindOf(s[m[2]:m[3]]) == 0 {
+			m = trailing.FindStringSubmatchIndex(s)
+		}
+		if m == nil || m[0] == 0 {
+			break
+		}
+		q := s[m[2]:m[3]]
+		t.Qualifiers = append([]string{q}, t.Qualifiers...)
+		t.Kind |= kindOf(q)
+		s = s[:m[0]]
+	}
+	t.Base = strings.TrimRight(CollapseSpace(s), ":,")
+	return t
+}
+
+// TitleWeights are the relative weights of the base titles, qualifiers,
+// and kinds when comparing titles.
+type TitleWeights struct {
+	Base, Qualifiers, Kind float64
+}
+
+// DefaultTitleWeights mostly compare base titles.
+var DefaultTitleWeights = TitleWeights{Base: 0.8, Qualifiers: 0.1, Kind: 0.1}
+
+// NewTitleComparer returns a comparer that parses both titles and compares
+// their base titles and qualifiers separately using f, and their kinds by
+// the proportion of flags they share, combined using w. Weights that sum
+// to zero, such as TitleWeights{}, mean DefaultTitleWeights.
+func NewTitleComparer(f Comparer, w TitleWeights) Comparer {
+	if w.Base+w.Qualifiers+w.Kind == 0 {
+		w = DefaultTitleWeights
+	}
+	total := w.Base + w.Qualifiers + w.Kind
+	return func(a, b string) float64 {
+		ta, tb := ParseTitle(a), ParseTitle(b)
+		q := 1.0
+		if len(ta.Qualifiers) > 0 || len(tb.Qualifiers) > 0 {
+			q = compareParts(f, strings.Join(ta.Qualifiers, " "),
+				strings.Join(tb.Qualifiers, " "))
+		}
+		k := 1.0
+		if ta.Kind|tb.Kind != 0 {
+			k = float64(bits.OnesCount(uint(ta.Kind&tb.Kind))) /
+				float64(bits.OnesCount(uint(ta.Kind|tb.Kind)))
+		}
+		base := compareParts(f, ta.Base, tb.Base)
+		return (w.Base*base + w.Qualifiers*q + w.Kind*k) / total
+	}
+}
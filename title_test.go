@@ -0,0 +1,106 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charles-haynes/strsim"
+)
+
+func TestParseTitle(t *testing.T) {
+	for _, c := range []struct {
+		s string
+		e strsim.Title
+	}{
+		{"Everything She Wants (Remix)", strsim.Title{
+			"Everything She Wants", []string{"Remix"}, strsim.Remix}},
+		{"Corail (Remixed)", strsim.Title{
+			"Corail", []string{"Remixed"}, strsim.Remix}},
+		{"Pink & Blue (RAC Mix)", strsim.Title{
+			"Pink & Blue", []string{"RAC Mix"}, strsim.Remix}},
+		{"John Wick: Chapter 2 (Original Motion Picture Soundtrack)", strsim.Title{
+			"John Wick: Chapter 2", []string{"Original Motion Picture Soundtrack"}, strsim.Soundtrack}},
+		{"Quentin Tarantino's Once Upon a Time in Hollywood Original Motion Picture Soundtrack", strsim.Title{
+			"Quentin Tarantino's Once Upon a Time in Hollywood", []string{"Original Motion Picture Soundtrack"}, strsim.Soundtrack}},
+		{"Mystic Warrior EP", strsim.Title{
+			"Mystic Warrior", []string{"EP"}, strsim.EP}},
+		{"Life of Leaf LP", strsim.Title{
+			"Life of Leaf", []string{"LP"}, strsim.LP}},
+		{"More Moonglow - The Rock Hard EP", strsim.Title{
+			"More Moonglow", []string{"The Rock Hard EP"}, strsim.EP}},
+		{"Remember The Night - Live at EPIC Prague, December 2018", strsim.Title{
+			"Remember The Night", []string{"Live at EPIC Prague, December 2018"}, strsim.Live}},
+		{"Road Chronicles: Live!", strsim.Title{
+			"Road Chronicles", []string{"Live!"}, strsim.Live}},
+		{"Jack Le Freak (Extended Remix '87)", strsim.Title{
+			"Jack Le Freak", []string{"Extended Remix '87"}, strsim.Remix}},
+		{"Jesus Christ Superstar - A Rock Opera", strsim.Title{
+			"Jesus Christ Superstar - A Rock Opera", nil, 0}},
+		{"Silent Piano (Songs for Sleeping) 2", strsim.Title{
+			"Silent Piano (Songs for Sleeping) 2", nil, 0}},
+		{"Live At Carnegie Hall", strsim.Title{
+			"Live At Carnegie Hall", nil, 0}},
+		{"EP", strsim.Title{"EP", nil, 0}},
+	} {
+		if r := strsim.ParseTitle(c.s); !reflect.DeepEqual(r, c.e) {
+			t.Errorf("ParseTitle(%s) = %#v, expected %#v", c.s, r, c.e)
+		}
+	}
+}
+
+func TestTitleComparer(t *testing.T) {
+	for s, f := range Sims {
+		tf := strsim.NewTitleComparer(f, strsim.DefaultTitleWeights)
+		bf := strsim.NewTitleComparer(f, strsim.TitleWeights{Base: 1})
+		for _, n := range [][]string{
+			{"Everything She Wants", "Everything She Wants (Remix)"},
+			{"Corail (Remixed)", "Corail"},
+			{"Pink & Blue (RAC Mix)", "Pink & Blue"},
+			{"John Wick: Chapter 2", "John Wick: Chapter 2 (Original Motion Picture Soundtrack)"},
+			{"Mystic Warrior", "Mystic Warrior EP"},
+			{"Life of Leaf LP", "Life of Leaf"},
+		} {
+			if r := tf(n[0], n[1]); r < 0.8 {
+				t.Errorf("%s(%s,%s) = %5.3f, expected >= 0.8",
+					s, n[0], n[1], r)
+			}
+			if r := bf(n[0], n[1]); r != 1.0 {
+				t.Errorf("%s base(%s,%s) = %5.3f, expected 1.0",
+					s, n[0], n[1], r)
+			}
+		}
+		if r := tf("Corail (Remixed)", "Corail (Remixed)"); r != 1.0 {
+			t.Errorf("%s(Corail (Remixed),Corail (Remixed)) = %5.3f, "+
+				"expected 1.0", s, r)
+		}
+		if r := tf("(Remix)", "(Remix)"); r != 1.0 {
+			t.Errorf("%s((Remix),(Remix)) = %5.3f, expected 1.0", s, r)
+		}
+		zf := strsim.NewTitleComparer(f, strsim.TitleWeights{})
+		a, b := "Corail (Remixed)", "Corail"
+		if r, e := zf(a, b), tf(a, b); r != e {
+			t.Errorf("%s zero(%s,%s) = %5.3f, expected %5.3f",
+				s, a, b, r, e)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Tokens returns the lower cased words of s, the runs of letters, marks and
+// numbers between anything else.
+func Tokens(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsMark(r) &&
+			!unicode.IsNumber(r)
+	})
+}
+
+// sortedTokens returns the tokens of s sorted and joined by spaces.
+func sortedTokens(s string) string {
+	t := Tokens(s)
+	sort.Strings(t)
+	return strings.Join(t, " ")
+}
+
+// TokenSort returns a comparer that compares the tokens of a and b with f,
+// after sorting them, so that strings that differ only in their word order,
+// case or punctuation are equal.
+func TokenSort(f Comparer) Comparer {
+	return func(a, b string) float64 {
+		return f(sortedTokens(a), sortedTokens(b))
+	}
+}
+
+// tokenSet returns the distinct tokens of s.
+func tokenSet(s string) map[string]bool {
+	m := map[string]bool{}
+	for _, t := range Tokens(s) {
+		m[t] = true
+	}
+	return m
+}
+
+// joinTokens returns the sorted tokens of ts joined by spaces.
+func joinTokens(ts []string) string {
+	sort.Strings(ts)
+	return strings.Join(ts, " ")
+}
+
+// TokenSet returns a comparer that splits the distinct tokens of a and b
+// into those they share and those only in one of them, and returns the
+// best score from f of the shared tokens, and the shared tokens followed by
+// those only in a or only in b. A string scores 1.0 against any string with
+// all of its tokens.
+func TokenSet(f Comparer) Comparer {
+	return func(a, b string) float64 {
+		ta, tb := tokenSet(a), tokenSet(b)
+		var common, onlyA, onlyB []string
+		for t := range ta {
+			if tb[t] {
+				common = append(common, t)
+			} else {
+				onlyA = append(onlyA, t)
+			}
+		}
+		for t := range tb {
+			if !ta[t] {
+				onlyB = append(onlyB, t)
+			}
+		}
+		t0 := joinTokens(common)
+		t1 := strings.TrimSpace(t0 + " " + joinTokens(onlyA))
+		t2 := strings.TrimSpace(t0 + " " + joinTokens(onlyB))
+		max := f(t1, t2)
+		if len(common) == 0 {
+			return max
+		}
+		if m := f(t0, t1); m > max {
+			max = m
+		}
+		if m := f(t0, t2); m > max {
+			max = m
+		}
+		return max
+	}
+}
+
+// PartialRatio returns a comparer that slides the shorter of a and b over
+// the longer, and returns the best score from f of the shorter and each
+// run of as many runes of the longer.
+func PartialRatio(f Comparer) Comparer {
+	return func(a, b string) float64 {
+		ra, rb := []rune(a), []rune(b)
+		if len(rb) < len(ra) {
+			a, ra, rb = b, rb, ra
+		}
+		if len(ra) == 0 || len(ra) == len(rb) {
+			return f(a, string(rb))
+		}
+		max := 0.0
+		for i := 0; i+len(ra) <= len(rb); i++ {
+			if m := f(a, string(rb[i:i+len(ra)])); m > max {
+				max = m
+				if max >= 1.0 {
+					break
+				}
+			}
+		}
+		return max
+	}
+}
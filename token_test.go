@@ -0,0 +1,81 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/charles-haynes/strsim"
+)
+
+func TestTokens(t *testing.T) {
+	for _, c := range []struct {
+		s string
+		e []string
+	}{
+		{"Apotheosis, Vol. 1: Mozart - The Final Quartets",
+			[]string{"apotheosis", "vol", "1", "mozart", "the", "final",
+				"quartets"}},
+		{"Stéphane Grappelli", []string{"stéphane", "grappelli"}},
+		{" - ", []string{}},
+	} {
+		if r := strsim.Tokens(c.s); !reflect.DeepEqual(r, c.e) {
+			t.Errorf("Tokens(%s) = %q, expected %q", c.s, r, c.e)
+		}
+	}
+}
+
+func TestTokenComparers(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		f    strsim.Comparer
+		a, b string
+		e    float64
+	}{
+		{"token sort", strsim.TokenSort(strsim.Levenshein),
+			"Apotheosis, Vol. 1: Mozart - The Final Quartets",
+			"Mozart: The Final Quartets (apotheosis vol. 1)", 1.0},
+		{"token sort", strsim.TokenSort(strsim.Levenshein), "b a", "a c",
+			2.0 / 3},
+		{"token set", strsim.TokenSet(strsim.Levenshein),
+			"Apotheosis, Vol. 1: Mozart - The Final Quartets",
+			"Mozart: The Final Quartets (apotheosis vol. 1)", 1.0},
+		{"token set", strsim.TokenSet(strsim.Levenshein), "new york mets",
+			"New York Mets vs Atlanta Braves", 1.0},
+		{"token set", strsim.TokenSet(strsim.Levenshein), "abc", "abd",
+			2.0 / 3},
+		{"partial ratio", strsim.PartialRatio(strsim.Levenshein), "FRKWYS",
+			"FRKWYS Vol. 15", 1.0},
+		{"partial ratio", strsim.PartialRatio(strsim.Levenshein),
+			"FRKWYS vol. 15", "Vol. 15", 6.0 / 7},
+		{"partial ratio", strsim.PartialRatio(strsim.Levenshein), "abc", "xbd",
+			1.0 / 3},
+		{"partial ratio", strsim.PartialRatio(strsim.Levenshein), "", "abc",
+			0.0},
+	} {
+		if r := c.f(c.a, c.b); math.Abs(r-c.e) > 1e-9 {
+			t.Errorf("%s(%s,%s) = %5.3f, expected %5.3f",
+				c.name, c.a, c.b, r, c.e)
+		}
+	}
+}
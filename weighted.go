@@ -0,0 +1,201 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim
+
+import (
+	"unicode"
+)
+
+// WeightedCosts give the cost of each edit as a function of the runes
+// involved. A nil function costs 1 for insertions and deletions and 2 for
+// substitutions, as Levenshein does. A substitution should cost no more
+// than deleting one rune and inserting the other.
+type WeightedCosts struct {
+	Insert     func(r rune) float64
+	Delete     func(r rune) float64
+	Substitute func(a, b rune) float64
+}
+
+func unitCost(rune) float64 {
+	return 1
+}
+
+func doubleCost(a, b rune) float64 {
+	return 2
+}
+
+// NewWeightedLevenshtein returns a comparer that computes the edit distance
+// between the runes of a and b using the costs c, normalized by the cost
+// of deleting all of a and inserting all of b.
+func NewWeightedLevenshtein(c WeightedCosts) Comparer {
+	ins, del, sub := c.Insert, c.Delete, c.Substitute
+	if ins == nil {
+		ins = unitCost
+	}
+	if del == nil {
+		del = unitCost
+	}
+	if sub == nil {
+		sub = doubleCost
+	}
+	return func(a, b string) float64 {
+		ra, rb := Runes(a, b)
+		row1 := make([]float64, len(rb)+1)
+		row2 := make([]float64, len(rb)+1)
+		for j, r := range rb {
+			row1[j+1] = row1[j] + ins(r)
+		}
+		max := row1[len(rb)]
+		for _, x := range ra {
+			d := del(x)
+			max += d
+			row2[0] = row1[0] + d
+			for j, y := range rb {
+				s := row1[j]
+				if x != y {
+					s += sub(x, y)
+				}
+				if i := row2[j] + ins(y); i < s {
+					s = i
+				}
+				if d := row1[j+1] + d; d < s {
+					s = d
+				}
+				row2[j+1] = s
+			}
+			row1, row2 = row2, row1
+		}
+		if max == 0 {
+			return 1.0
+		}
+		if s := 1.0 - row1[len(rb)]/max; s > 0 {
+			return s
+		}
+		return 0.0
+	}
+}
+
+// RuneCost returns an insertion or deletion cost function that charges
+// punct for punctuation and white space, digit for digits and other for
+// anything else.
+func RuneCost(punct, digit, other float64) func(r rune) float64 {
+	return func(r rune) float64 {
+		switch {
+		case unicode.IsPunct(r) || unicode.IsSpace(r):
+			return punct
+		case unicode.IsDigit(r):
+			return digit
+		}
+		return other
+	}
+}
+
+// Confusions are the costs of substituting pairs of runes that are easily
+// confused for one another. Pairs are unordered, and are kept with the
+// lesser rune first, as ConfusionPair makes them.
+type Confusions map[[2]rune]float64
+
+// ConfusionPair returns the key of the pair of a and b in Confusions.
+func ConfusionPair(a, b rune) [2]rune {
+	if b < a {
+		a, b = b, a
+	}
+	return [2]rune{a, b}
+}
+
+// Cost returns a substitution cost function that uses the cost in cs, or
+// base for pairs not in cs. If base is nil it costs 2. Pairs in cs that are
+// not kept lesser rune first, or are in cs both ways round, are merged as
+// Merge does, so the cost is the same whichever way round a and b are.
+func (cs Confusions) Cost(base func(a, b rune) float64) func(a, b rune) float64 {
+	if base == nil {
+		base = doubleCost
+	}
+	cs = cs.Merge()
+	return func(a, b rune) float64 {
+		if c, ok := cs[ConfusionPair(a, b)]; ok {
+			return c
+		}
+		return base(a, b)
+	}
+}
+
+// OCRConfusions returns Confusions costing cost for runes that OCR often
+// mistakes for each other, such as 0 and O.
+func OCRConfusions(cost float64) Confusions {
+	cs := Confusions{}
+	for _, p := range []string{
+		"0O", "0o", "0D", "Oo", "1l", "1I", "1i", "Il", "il", "5S", "5s",
+		"Ss", "8B", "2Z", "2z", "Zz", "6b", "9g", "9q", "Cc", "Vv", "Ww",
+		"Xx", "uv", "ce",
+	} {
+		r := []rune(p)
+		cs[ConfusionPair(r[0], r[1])] = cost
+	}
+	return cs
+}
+
+var qwerty = []string{"1234567890", "qwertyuiop", "asdfghjkl", "zxcvbnm"}
+
+// QwertyConfusions returns Confusions costing cost for keys that are next
+// to each other on a QWERTY keyboard, in either case.
+func QwertyConfusions(cost float64) Confusions {
+	cs := Confusions{}
+	add := func(a, b byte) {
+		for _, x := range []rune{rune(a), unicode.ToUpper(rune(a))} {
+			for _, y := range []rune{rune(b), unicode.ToUpper(rune(b))} {
+				cs[ConfusionPair(x, y)] = cost
+			}
+		}
+	}
+	for r, row := range qwerty {
+		for i := 0; i < len(row); i++ {
+			if i+1 < len(row) {
+				add(row[i], row[i+1])
+			}
+			if r+1 < len(qwerty) {
+				below := qwerty[r+1]
+				if i < len(below) {
+					add(row[i], below[i])
+				}
+				if i > 0 && i-1 < len(below) {
+					add(row[i], below[i-1])
+				}
+			}
+		}
+	}
+	return cs
+}
+
+// Merge returns Confusions with the pairs in cs and all of others, kept
+// lesser rune first, using the lowest cost for pairs in more than one.
+func (cs Confusions) Merge(others ...Confusions) Confusions {
+	m := Confusions{}
+	for _, c := range append([]Confusions{cs}, others...) {
+		for p, v := range c {
+			p = ConfusionPair(p[0], p[1])
+			if o, ok := m[p]; !ok || v < o {
+				m[p] = v
+			}
+		}
+	}
+	return m
+}
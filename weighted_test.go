@@ -0,0 +1,83 @@
+// Copyright © 2018 Charles Haynes <ceh@ceh.bz>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package strsim_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/charles-haynes/strsim"
+)
+
+func TestWeightedLevenshtein(t *testing.T) {
+	for _, n := range GroupsEqual {
+		e := strsim.Levenshein(n[0], n[1])
+		r := strsim.NewWeightedLevenshtein(strsim.WeightedCosts{})(n[0], n[1])
+		if math.Abs(r-e) > 1e-9 {
+			t.Errorf("(%s,%s) = %5.3f, expected %5.3f", n[0], n[1], r, e)
+		}
+	}
+	indel := strsim.RuneCost(0.1, 2, 1)
+	ocr := strsim.NewWeightedLevenshtein(strsim.WeightedCosts{
+		Insert:     indel,
+		Delete:     indel,
+		Substitute: strsim.OCRConfusions(0.2).Cost(nil),
+	})
+	keys := strsim.NewWeightedLevenshtein(strsim.WeightedCosts{
+		Substitute: strsim.QwertyConfusions(0.5).Cost(nil),
+	})
+	for _, c := range []struct {
+		f    strsim.Comparer
+		a, b string
+		e    float64
+	}{
+		{ocr, "CAT-0042", "CAT-OO42", 1 - 0.4/20.2},
+		{ocr, "Back to Mine", "Back to Mine!", 1 - 0.1/20.5},
+		{ocr, "Episode 1", "Episode 2", 1 - 2.0/18.2},
+		{ocr, "", "", 1.0},
+		{keys, "Jeezy", "Jeezt", 1 - 0.5/10},
+		{keys, "Jeezy", "JeezY", 1 - 2.0/10},
+		{keys, "Jeezy", "JEEZT", 1 - 6.5/10},
+		{keys, "Jeezy", "Jeezq", 1 - 2.0/10},
+	} {
+		if r := c.f(c.a, c.b); math.Abs(r-c.e) > 1e-9 {
+			t.Errorf("(%s,%s) = %5.3f, expected %5.3f", c.a, c.b, r, c.e)
+		}
+	}
+	m := strsim.OCRConfusions(0.2).Merge(strsim.QwertyConfusions(0.5))
+	if m[strsim.ConfusionPair('0', 'O')] != 0.2 ||
+		m[strsim.ConfusionPair('p', 'o')] != 0.5 {
+		t.Errorf("Merge = %v", m)
+	}
+	cost := strsim.Confusions{{'a', 'b'}: 0.5, {'b', 'a'}: 1.0,
+		{'d', 'c'}: 0.3}.Cost(nil)
+	for _, c := range []struct {
+		a, b rune
+		e    float64
+	}{
+		{'a', 'b', 0.5}, {'b', 'a', 0.5}, {'c', 'd', 0.3}, {'d', 'c', 0.3},
+		{'a', 'c', 2.0},
+	} {
+		if r := cost(c.a, c.b); r != c.e {
+			t.Errorf("Cost(%c,%c) = %5.3f, expected %5.3f", c.a, c.b, r, c.e)
+		}
+	}
+}